@@ -39,7 +39,8 @@ func main() {
 	// Create and register middlewares
 	tracing.ApplyMiddleware(server, conf.Tracing)
 	logging.ApplyMiddleware(server)
-	auth.ApplyMiddleware(server, conf.Auth)
+	// The abilities completing their jobs authenticate with the signature of the request, they have no app token.
+	auth.ApplyMiddleware(server, conf.Auth, "/api/v1/abilities/:name/jobs/:id")
 
 	// Create and register handlers
 	handlers := handler.New(conf)
@@ -47,6 +48,10 @@ func main() {
 	apiV1.POST("/talk/text", handlers.Text)
 	apiV1.GET("/abilities", handlers.GetAbilities)
 	apiV1.POST("/abilities", handlers.CreateAbility)
+	apiV1.POST("/abilities/:name/heartbeat", handlers.Heartbeat)
+	apiV1.GET("/shadows/diffs", handlers.GetShadowDiffs)
+	apiV1.POST("/abilities/:name/jobs/:id", handlers.CompleteJob)
+	apiV1.GET("/notifications", handlers.Notifications)
 
 	// Run the echo server
 	logrus.Fatal(server.Start(fmt.Sprintf(":%d", conf.Server.Port)))
@@ -0,0 +1,107 @@
+package ability
+
+import (
+	"context"
+
+	"github.com/milobella/oratio/pkg/ability"
+	"github.com/milobella/oratio/pkg/cerebro"
+	"github.com/sirupsen/logrus"
+)
+
+// arbitration is the policy used to pick the winning response when several abilities are requested concurrently.
+type arbitration string
+
+const (
+	// arbitrationScore picks the successful response coming from the best scored intent.
+	arbitrationScore arbitration = "score"
+	// arbitrationConfidence picks the successful response in which the ability is the most confident.
+	arbitrationConfidence arbitration = "confidence"
+	// arbitrationFirst picks the first successful response, without waiting for the other ones.
+	arbitrationFirst arbitration = "first"
+)
+
+func newArbitration(policy string) arbitration {
+	switch arbitration(policy) {
+	case arbitrationScore, arbitrationConfidence, arbitrationFirst:
+		return arbitration(policy)
+	case "":
+		return arbitrationScore
+	default:
+		logrus.WithField("arbitration", policy).Warnf("Unknown arbitration policy. Default to %s", arbitrationScore)
+		return arbitrationScore
+	}
+}
+
+// prefers tells whether the outcome o should win over the current winner.
+func (a arbitration) prefers(o outcome, winner outcome) bool {
+	if a == arbitrationConfidence && o.response.Confidence != winner.response.Confidence {
+		return o.response.Confidence > winner.response.Confidence
+	}
+	return o.rank < winner.rank
+}
+
+// candidate is an ability client resolved from one of the NLU intents.
+type candidate struct {
+	intent cerebro.Intent
+	client *ability.Client
+	// cacheable tells whether the client can be cached by intent, its ability having a single version.
+	cacheable bool
+}
+
+// outcome is the result of the call to a candidate, ranked as the candidate was.
+type outcome struct {
+	rank     int
+	response *ability.Response
+	// called tells whether the ability has been requested, its call not being short-circuited by a hook.
+	called bool
+	err    error
+}
+
+// callCandidates requests all the candidates concurrently and arbitrates between their successful responses.
+// If none of the candidates succeeded, the error of the best ranked one is returned.
+func (s *serviceImpl) callCandidates(ctx context.Context, candidates []candidate, request ability.Request) (*candidate, *outcome, error) {
+	if len(candidates) == 1 {
+		response, called, err := s.callAbility(ctx, candidates[0].client, request)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &candidates[0], &outcome{response: response, called: called}, nil
+	}
+
+	// The channel is buffered so that the calls we don't wait for (first success policy) don't leak.
+	outcomes := make(chan outcome, len(candidates))
+	for rank, c := range candidates {
+		go func(rank int, c candidate) {
+			response, called, err := s.callAbility(ctx, c.client, request)
+			outcomes <- outcome{rank: rank, response: response, called: called, err: err}
+		}(rank, c)
+	}
+
+	var winner *outcome
+	errs := make([]error, len(candidates))
+	for range candidates {
+		o := <-outcomes
+		if o.err != nil {
+			errs[o.rank] = o.err
+			continue
+		}
+		if winner == nil || s.arbitration.prefers(o, *winner) {
+			winner = &o
+		}
+		if s.arbitration == arbitrationFirst {
+			break
+		}
+	}
+
+	if winner == nil {
+		return nil, nil, errs[0]
+	}
+
+	logrus.
+		WithField("arbitration", s.arbitration).
+		WithField("candidates", len(candidates)).
+		WithField("intent", candidates[winner.rank].intent.Label).
+		WithField("client", candidates[winner.rank].client.Name).
+		Debug("Arbitrated between the competing abilities.")
+	return &candidates[winner.rank], winner, nil
+}
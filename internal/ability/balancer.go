@@ -0,0 +1,36 @@
+package ability
+
+import (
+	"sync"
+
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+)
+
+// loadBalancers keeps the load balancer of every ability by key (name and version), so that the round robin position
+// and the calls in flight of its instances survive the clients being rebuilt from the database.
+type loadBalancers struct {
+	mutex sync.Mutex
+	byKey map[string]loadBalancerEntry
+}
+
+type loadBalancerEntry struct {
+	policy       string
+	loadBalancer *ability.LoadBalancer
+}
+
+func newLoadBalancers() *loadBalancers {
+	return &loadBalancers{byKey: make(map[string]loadBalancerEntry)}
+}
+
+// get returns the load balancer of the ability, a new one if its policy changed.
+func (l *loadBalancers) get(ab *model.Ability) *ability.LoadBalancer {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if entry, ok := l.byKey[ab.Key()]; ok && entry.policy == ab.LoadBalancing {
+		return entry.loadBalancer
+	}
+	loadBalancer := ability.NewLoadBalancer(ability.LoadBalancing(ab.LoadBalancing))
+	l.byKey[ab.Key()] = loadBalancerEntry{policy: ab.LoadBalancing, loadBalancer: loadBalancer}
+	return loadBalancer
+}
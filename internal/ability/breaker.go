@@ -0,0 +1,51 @@
+package ability
+
+import (
+	"sync"
+	"time"
+
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+)
+
+// breakers keeps the circuit breaker of every ability by key (name and version), so that its state survives the clients being rebuilt
+// from the database.
+type breakers struct {
+	mutex sync.Mutex
+	byKey map[string]breakerEntry
+}
+
+type breakerEntry struct {
+	conf    model.CircuitBreaker
+	breaker *ability.CircuitBreaker
+}
+
+func newBreakers() *breakers {
+	return &breakers{byKey: make(map[string]breakerEntry)}
+}
+
+// get returns the circuit breaker of the ability, a new one if its configuration changed, or nil if it has none.
+func (b *breakers) get(ab *model.Ability) *ability.CircuitBreaker {
+	if ab.CircuitBreaker == nil || ab.CircuitBreaker.FailureThreshold <= 0 {
+		return nil
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if entry, ok := b.byKey[ab.Key()]; ok && entry.conf == *ab.CircuitBreaker {
+		return entry.breaker
+	}
+	breaker := ability.NewCircuitBreaker(ab.Key(), ab.CircuitBreaker.FailureThreshold, time.Duration(ab.CircuitBreaker.CoolDown))
+	b.byKey[ab.Key()] = breakerEntry{conf: *ab.CircuitBreaker, breaker: breaker}
+	return breaker
+}
+
+// state returns the state of the circuit breaker of the ability, empty if it has none.
+func (b *breakers) state(key string) string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if entry, ok := b.byKey[key]; ok {
+		return string(entry.breaker.State())
+	}
+	return ""
+}
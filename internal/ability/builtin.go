@@ -0,0 +1,88 @@
+package ability
+
+import (
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/pkg/ability"
+	"github.com/sirupsen/logrus"
+)
+
+// builtinFallbackSentence is given when a templated built-in sentence fails to render, rather than its source.
+const builtinFallbackSentence = "Sorry, I can't answer that right now."
+
+// builtins indexes by intent the responses configured to be given by oratio itself, without any ability behind.
+type builtins map[string]*builtin
+
+type builtin struct {
+	conf     config.Builtin
+	template *template.Template
+}
+
+// builtinData is what a templated built-in sentence can use.
+type builtinData struct {
+	Text     string
+	Entities map[string]string
+	State    map[string]interface{}
+}
+
+func newBuiltins(confs []config.Builtin) builtins {
+	result := make(builtins, len(confs))
+	for _, conf := range confs {
+		b := &builtin{conf: conf}
+		if conf.Template {
+			tmpl, err := template.New(strings.Join(conf.Intents, ",")).Parse(conf.Nlg.Sentence)
+			if err == nil {
+				// Rendering it with empty data catches the fields which don't exist, a typo for example.
+				err = tmpl.Execute(io.Discard, builtinData{})
+			}
+			if err != nil {
+				logrus.WithError(err).WithField("intents", conf.Intents).Error("Invalid built-in intent template, skipping it.")
+				continue
+			}
+			b.template = tmpl
+		}
+		for _, intent := range conf.Intents {
+			result[intent] = b
+		}
+	}
+	return result
+}
+
+// respond builds the response of the built-in intent, rendering its sentence if it is a template.
+func (b builtins) respond(intent string, request ability.Request) (*ability.Response, bool) {
+	builtin, ok := b[intent]
+	if !ok {
+		return nil, false
+	}
+
+	response := &ability.Response{
+		Nlg:          builtin.conf.Nlg,
+		Visu:         builtin.conf.Visu,
+		Actions:      builtin.conf.Actions,
+		AutoReprompt: builtin.conf.AutoReprompt,
+	}
+	if builtin.template == nil {
+		return response, true
+	}
+
+	data := builtinData{
+		Text:     request.Nlu.Text,
+		Entities: make(map[string]string, len(request.Nlu.Entities)),
+		State:    request.Device.State,
+	}
+	for _, entity := range request.Nlu.Entities {
+		data.Entities[entity.Label] = entity.Text
+	}
+
+	var sentence strings.Builder
+	if err := builtin.template.Execute(&sentence, data); err != nil {
+		logrus.WithError(err).WithField("intent", intent).Error("Error rendering the built-in intent template.")
+		response.Nlg.Sentence = builtinFallbackSentence
+		return response, true
+	}
+	response.Nlg.Sentence = sentence.String()
+	return response, true
+}
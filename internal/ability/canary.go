@@ -0,0 +1,140 @@
+package ability
+
+import (
+	"context"
+	"hash/fnv"
+	"math/rand"
+
+	"github.com/milobella/oratio/internal/model"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// splitTraffic orders the abilities so that, among the versions of a same ability, the one the device falls on comes
+// first, followed by the other versions as fallback. The device ID being hashed, a device always falls on the same
+// version as long as the traffic shares don't change. Without device ID, the version is drawn randomly.
+func splitTraffic(ctx context.Context, abilities []*model.Ability, deviceID string) []*model.Ability {
+	versions := make(map[string][]*model.Ability, len(abilities))
+	names := make([]string, 0, len(abilities))
+	for _, ab := range abilities {
+		if _, ok := versions[ab.Name]; !ok {
+			names = append(names, ab.Name)
+		}
+		versions[ab.Name] = append(versions[ab.Name], ab)
+	}
+
+	result := make([]*model.Ability, 0, len(abilities))
+	for _, name := range names {
+		result = append(result, pickVersion(ctx, versions[name], deviceID)...)
+	}
+	return result
+}
+
+// fullTraffic is the traffic of an ability, shared between its versions.
+const fullTraffic = 100
+
+// trafficShares computes the share of every version, as a percentage: the versions without a share split what the other
+// ones leave of 100 equally. If the shares don't add up to 100 anyway, they are scaled to it.
+func trafficShares(versions []*model.Ability) []int {
+	shares := make([]int, len(versions))
+	shared, unshared := 0, 0
+	for _, version := range versions {
+		if version.Traffic > 0 {
+			shared += version.Traffic
+		} else {
+			unshared++
+		}
+	}
+	remainder := 0
+	if unshared > 0 && shared < fullTraffic {
+		remainder = fullTraffic - shared
+	}
+	for i, version := range versions {
+		switch {
+		case version.Traffic > 0:
+			shares[i] = version.Traffic
+		case remainder > 0:
+			// The first versions without a share get what the division leaves.
+			shares[i] = remainder / unshared
+			if i < remainder%unshared {
+				shares[i]++
+			}
+		}
+	}
+	if total := shared + remainder; total != fullTraffic && total > 0 {
+		logrus.
+			WithField("ability", versions[0].Name).
+			WithField("total", total).
+			Warn("The traffic shares of the versions of the ability don't add up to 100, scaling them.")
+		for i := range shares {
+			shares[i] = shares[i] * fullTraffic / total
+		}
+	}
+	return shares
+}
+
+// pickVersion moves first the version of the ability the device falls on, according to the traffic shares.
+func pickVersion(ctx context.Context, versions []*model.Ability, deviceID string) []*model.Ability {
+	if len(versions) < 2 {
+		return versions
+	}
+	shares := trafficShares(versions)
+	total := 0
+	for _, share := range shares {
+		total += share
+	}
+	if total <= 0 {
+		return versions
+	}
+
+	var bucket int
+	if deviceID != "" {
+		hash := fnv.New32a()
+		_, _ = hash.Write([]byte(deviceID + "/" + versions[0].Name))
+		bucket = int(hash.Sum32() % uint32(total))
+	} else {
+		bucket = rand.Intn(total)
+	}
+
+	picked := 0
+	for cumulated := shares[0]; bucket >= cumulated; cumulated += shares[picked] {
+		picked++
+	}
+
+	logrus.
+		WithField("ability", versions[picked].Name).
+		WithField("version", versions[picked].Version).
+		WithField("traffic", shares[picked]).
+		WithField("deviceID", deviceID).
+		Debug("Split the traffic between the versions of the ability.")
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.String("ability.split.name", versions[picked].Name),
+		attribute.String("ability.split.version", versions[picked].Version),
+		attribute.Int("ability.split.traffic", shares[picked]),
+		attribute.Bool("ability.split.sticky", deviceID != ""),
+	)
+
+	ordered := make([]*model.Ability, 0, len(versions))
+	ordered = append(ordered, versions[picked])
+	ordered = append(ordered, versions[:picked]...)
+	return append(ordered, versions[picked+1:]...)
+}
+
+// countVersions counts the versions of the ability among the abilities.
+func countVersions(abilities []*model.Ability, name string) int {
+	count := 0
+	for _, ab := range abilities {
+		if ab.Name == name {
+			count++
+		}
+	}
+	return count
+}
+
+// hasSeveralVersions tells whether several versions of the ability are registered in the database, its traffic being
+// then split between them. The database failing, the cached clients are kept.
+func (s *serviceImpl) hasSeveralVersions(name string) bool {
+	versions, err := s.dao.GetByName(name)
+	return err == nil && countVersions(versions, name) > 1
+}
@@ -0,0 +1,167 @@
+package ability
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+	"github.com/milobella/oratio/pkg/cerebro"
+)
+
+func versionsOf(traffics ...int) []*model.Ability {
+	versions := make([]*model.Ability, 0, len(traffics))
+	for i, traffic := range traffics {
+		versions = append(versions, &model.Ability{Name: "clock", Version: fmt.Sprintf("v%d", i+1), Traffic: traffic})
+	}
+	return versions
+}
+
+func versionNames(abilities []*model.Ability) []string {
+	names := make([]string, 0, len(abilities))
+	for _, ab := range abilities {
+		names = append(names, ab.Version)
+	}
+	return names
+}
+
+func TestPickVersionIsSticky(t *testing.T) {
+	versions := versionsOf(50, 50)
+	for i := 0; i < 20; i++ {
+		deviceID := fmt.Sprintf("device-%d", i)
+		first := pickVersion(context.Background(), versions, deviceID)[0]
+		for j := 0; j < 10; j++ {
+			if picked := pickVersion(context.Background(), versions, deviceID)[0]; picked != first {
+				t.Fatalf("pickVersion(%s) = %s, then %s", deviceID, first.Version, picked.Version)
+			}
+		}
+	}
+}
+
+func TestPickVersionSplitsTheTraffic(t *testing.T) {
+	tests := []struct {
+		name     string
+		traffics []int
+		// never lists the versions no device must fall on.
+		never []string
+	}{
+		{"even split", []int{50, 50}, nil},
+		{"canary", []int{90, 10}, nil},
+		{"version without share left nothing", []int{80, 0, 20}, []string{"v2"}},
+		{"single live version", []int{0, 100}, []string{"v1"}},
+		{"version without share getting the remainder", []int{0, 10}, nil},
+		{"versions without share", []int{0, 0}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions := versionsOf(tt.traffics...)
+			picked := make(map[string]int)
+			for i := 0; i < 1000; i++ {
+				ordered := pickVersion(context.Background(), versions, fmt.Sprintf("device-%d", i))
+				if len(ordered) != len(versions) {
+					t.Fatalf("pickVersion() returned %d versions, want %d", len(ordered), len(versions))
+				}
+				picked[ordered[0].Version]++
+			}
+			for _, version := range tt.never {
+				if picked[version] > 0 {
+					t.Errorf("%d devices fell on %s, which has no traffic", picked[version], version)
+				}
+			}
+			for i, traffic := range trafficShares(versions) {
+				// Every version with traffic gets roughly its share, within 5 points.
+				version := fmt.Sprintf("v%d", i+1)
+				if share := picked[version] / 10; traffic > 0 && (share < traffic-5 || share > traffic+5) {
+					t.Errorf("%s got %d%% of the devices, want about %d%%", version, share, traffic)
+				}
+			}
+		})
+	}
+}
+
+func TestTrafficShares(t *testing.T) {
+	tests := []struct {
+		name     string
+		traffics []int
+		want     []int
+	}{
+		{"percentages", []int{90, 10}, []int{90, 10}},
+		{"remainder to the version without share", []int{0, 10}, []int{90, 10}},
+		{"remainder split", []int{0, 0, 10}, []int{45, 45, 10}},
+		{"remainder split unevenly", []int{0, 0, 0}, []int{34, 33, 33}},
+		{"nothing left", []int{0, 100}, []int{0, 100}},
+		{"too much scaled", []int{100, 100}, []int{50, 50}},
+		{"too much scaled with a version without share", []int{0, 150, 50}, []int{0, 75, 25}},
+		{"too little scaled", []int{20, 30}, []int{40, 60}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := trafficShares(versionsOf(tt.traffics...)); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("trafficShares(%v) = %v, want %v", tt.traffics, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickVersionKeepsTheOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []*model.Ability
+		want     []string
+	}{
+		{"single version", versionsOf(10), []string{"v1"}},
+		{"others kept as fallback", versionsOf(0, 0, 100), []string{"v3", "v1", "v2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := versionNames(pickVersion(context.Background(), tt.versions, "device"))
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("pickVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitTrafficGroupsByAbility(t *testing.T) {
+	abilities := append(versionsOf(0, 100), &model.Ability{Name: "cinema"})
+	got := splitTraffic(context.Background(), abilities, "device")
+	want := []string{"clock@v2", "clock@v1", "cinema"}
+	for i, ab := range got {
+		if ab.Key() != want[i] {
+			t.Errorf("splitTraffic()[%d] = %s, want %s", i, ab.Key(), want[i])
+		}
+	}
+}
+
+func TestRolloutOfANewVersion(t *testing.T) {
+	server := newAbilitiesServer(t, map[string]*ability.Response{
+		"clock":    ability.NewSimpleResponse("clock"),
+		"clock-v2": ability.NewSimpleResponse("clock@v2"),
+	})
+	s := newTestService(config.Abilities{}, &model.Ability{Name: "clock", Intents: []string{"GET_TIME"}, URL: server.URL + "/clock"})
+	nlu := cerebro.NLU{Intents: []cerebro.Intent{{Label: "GET_TIME", Score: 1}}}
+
+	// The unversioned ability is cached while it is the only version.
+	if response := s.RequestAbility(context.Background(), nlu, ability.Context{}, ability.Device{ID: "device"}); response.Nlg.Sentence != "clock" {
+		t.Fatalf("RequestAbility() = %q, want clock", response.Nlg.Sentence)
+	}
+	if _, cached := s.clientsCache.Get("GET_TIME"); !cached {
+		t.Fatalf("the client of the single version is not cached")
+	}
+
+	// A new version gets its share of the traffic right away, even from an instance of oratio which cached the client.
+	_, _ = s.dao.CreateOrUpdate(&model.Ability{Name: "clock", Version: "v2", Traffic: 10, Intents: []string{"GET_TIME"}, URL: server.URL + "/clock-v2"})
+	picked := make(map[string]int)
+	for i := 0; i < 500; i++ {
+		response := s.RequestAbility(context.Background(), nlu, ability.Context{}, ability.Device{ID: fmt.Sprintf("device-%d", i)})
+		picked[response.Nlg.Sentence]++
+	}
+	if share := picked["clock@v2"] / 5; share < 5 || share > 15 {
+		t.Errorf("clock@v2 got %d%% of the devices, want about 10%%", share)
+	}
+	if _, cached := s.clientsCache.Get("GET_TIME"); cached {
+		t.Errorf("the client is still cached while the traffic is split between the versions")
+	}
+}
@@ -0,0 +1,86 @@
+package ability
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+)
+
+const (
+	credentialsBearer   = "bearer"
+	credentialsAPIKey   = "api_key"
+	defaultAPIKeyHeader = "X-API-Key"
+)
+
+// secrets indexes the secrets of the configuration by name. The names are case-insensitive, as the configuration
+// keys.
+type secrets map[string]config.Secret
+
+func newSecrets(conf map[string]config.Secret) secrets {
+	result := make(secrets, len(conf))
+	for name, secret := range conf {
+		result[strings.ToLower(name)] = secret
+	}
+	return result
+}
+
+// get returns the secret the credentials of the ability reference, if every instance of the ability is served by one
+// of the hosts it can be sent to.
+func (s secrets) get(ab *model.Ability) (config.Secret, error) {
+	secret, ok := s[strings.ToLower(ab.Credentials.Secret)]
+	if !ok {
+		return secret, fmt.Errorf("unknown secret %q", ab.Credentials.Secret)
+	}
+	for _, i := range ab.AllInstances() {
+		host := i.Host
+		if i.URL != "" {
+			u, err := url.Parse(i.URL)
+			if err != nil {
+				return secret, err
+			}
+			host = u.Hostname()
+		}
+		if !allowedHost(secret.Hosts, host) {
+			return secret, fmt.Errorf("the secret %q can't be sent to %s", ab.Credentials.Secret, host)
+		}
+	}
+	return secret, nil
+}
+
+func allowedHost(hosts []string, host string) bool {
+	for _, allowed := range hosts {
+		if strings.EqualFold(allowed, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// credentialsOption sends the credentials of the ability with every request, or returns nil if it has none. It fails
+// if the credentials reference a secret which is unknown, or which can't be sent to the instances of the ability.
+func (s *serviceImpl) credentialsOption(ab *model.Ability) (ability.ClientOption, error) {
+	if ab.Credentials == nil {
+		return nil, nil
+	}
+	secret, err := s.secrets.get(ab)
+	if err != nil {
+		return nil, fmt.Errorf("resolving the credentials: %w", err)
+	}
+	value := ability.Secret{Env: secret.Env, File: secret.File}
+	switch ab.Credentials.Type {
+	case credentialsBearer:
+		return ability.WithBearerToken(value), nil
+	case credentialsAPIKey:
+		header := ab.Credentials.Header
+		if header == "" {
+			header = defaultAPIKeyHeader
+		}
+		return ability.WithAPIKey(header, value), nil
+	default:
+		return nil, fmt.Errorf("unknown credentials type %q", ab.Credentials.Type)
+	}
+}
@@ -0,0 +1,71 @@
+package ability
+
+import (
+	"testing"
+
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/internal/model"
+)
+
+func TestCredentialsOption(t *testing.T) {
+	s := &serviceImpl{secrets: newSecrets(map[string]config.Secret{
+		"translate": {Env: "TRANSLATE_API_KEY", Hosts: []string{"api.example.com"}},
+		"orphan":    {Env: "ORPHAN_API_KEY"},
+	})}
+
+	tests := []struct {
+		name      string
+		ability   model.Ability
+		wantErr   bool
+		wantCreds bool
+	}{
+		{
+			name:    "no credentials",
+			ability: model.Ability{Name: "clock", URL: "https://attacker.example.com"},
+		},
+		{
+			name:      "allowed host",
+			ability:   model.Ability{Name: "translate", URL: "https://api.example.com/translate", Credentials: &model.Credentials{Type: "api_key", Secret: "translate"}},
+			wantCreds: true,
+		},
+		{
+			name:      "case-insensitive name and host",
+			ability:   model.Ability{Name: "translate", Host: "API.example.com", Port: 443, Credentials: &model.Credentials{Type: "bearer", Secret: "Translate"}},
+			wantCreds: true,
+		},
+		{
+			name:    "other host",
+			ability: model.Ability{Name: "translate", URL: "https://attacker.example.com", Credentials: &model.Credentials{Type: "bearer", Secret: "translate"}},
+			wantErr: true,
+		},
+		{
+			name: "one instance on another host",
+			ability: model.Ability{Name: "translate", URL: "https://api.example.com", Credentials: &model.Credentials{Type: "bearer", Secret: "translate"},
+				Instances: []model.Instance{{Host: "attacker.example.com", Port: 443}}},
+			wantErr: true,
+		},
+		{
+			name:    "secret without hosts",
+			ability: model.Ability{Name: "orphan", URL: "https://api.example.com", Credentials: &model.Credentials{Type: "bearer", Secret: "orphan"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown secret",
+			ability: model.Ability{Name: "translate", URL: "https://api.example.com", Credentials: &model.Credentials{Type: "bearer", Secret: "ORATIO_AUTH_APP_SECRET"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown type",
+			ability: model.Ability{Name: "translate", URL: "https://api.example.com", Credentials: &model.Credentials{Type: "basic", Secret: "translate"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			option, err := s.credentialsOption(&tt.ability)
+			if (err != nil) != tt.wantErr || (option != nil) != tt.wantCreds {
+				t.Errorf("credentialsOption() = %v, %v, want credentials: %v, error: %v", option != nil, err, tt.wantCreds, tt.wantErr)
+			}
+		})
+	}
+}
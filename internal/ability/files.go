@@ -0,0 +1,181 @@
+package ability
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// fileReloadDelay gathers the events of the files changed together (a Kubernetes ConfigMap update for example) in a
+// single reload.
+const fileReloadDelay = 100 * time.Millisecond
+
+// fileAbilities keeps the abilities described by the files of the discovery directory, in sync with the files.
+type fileAbilities struct {
+	directory string
+	mutex     sync.RWMutex
+	current   *abilitySet
+	// reloading runs the reloads one at a time, the timers of the events being able to fire together. It guards byFile.
+	reloading sync.Mutex
+	// byFile keeps the abilities of every file, so that a file which can't be parsed anymore keeps its last abilities.
+	byFile map[string][]model.Ability
+}
+
+// watchFileAbilities loads the abilities of the files of the directory, then watches it to reload them when the files
+// are added, changed or removed. The set is empty if the discovery is disabled.
+func (s *serviceImpl) watchFileAbilities(conf config.Files) *fileAbilities {
+	f := &fileAbilities{directory: conf.Directory, current: s.newAbilitySet(nil), byFile: make(map[string][]model.Ability)}
+	if f.directory == "" {
+		return f
+	}
+	s.reloadFileAbilities(f)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err == nil {
+		err = watcher.Add(f.directory)
+	}
+	if err != nil {
+		logrus.WithError(err).WithField("directory", f.directory).Error("Error watching the abilities directory.")
+		return f
+	}
+	go s.watchFiles(f, watcher)
+	return f
+}
+
+func (s *serviceImpl) watchFiles(f *fileAbilities, watcher *fsnotify.Watcher) {
+	var reload *time.Timer
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			logrus.WithField("file", event.Name).WithField("op", event.Op.String()).Debug("Abilities directory changed.")
+			if reload != nil {
+				reload.Stop()
+			}
+			reload = time.AfterFunc(fileReloadDelay, func() { s.reloadFileAbilities(f) })
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			logrus.WithError(err).WithField("directory", f.directory).Error("Error watching the abilities directory.")
+		}
+	}
+}
+
+// set returns the abilities currently described by the files.
+func (f *fileAbilities) set() *abilitySet {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	return f.current
+}
+
+// reloadFileAbilities reads every file of the directory and replaces the abilities with theirs.
+func (s *serviceImpl) reloadFileAbilities(f *fileAbilities) {
+	f.reloading.Lock()
+	defer f.reloading.Unlock()
+
+	entries, err := os.ReadDir(f.directory)
+	if err != nil {
+		logrus.WithError(err).WithField("directory", f.directory).Error("Error reading the abilities directory.")
+		return
+	}
+
+	byFile := make(map[string][]model.Ability, len(entries))
+	for _, entry := range entries {
+		// The hidden files are skipped, as the data of the Kubernetes ConfigMaps behind their symbolic links.
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".json" && ext != ".toml" {
+			continue
+		}
+		abilities, err := readAbilitiesFile(filepath.Join(f.directory, name), ext)
+		for i := 0; err == nil && i < len(abilities); i++ {
+			err = s.validateAbility(&abilities[i])
+		}
+		if err != nil {
+			logrus.WithError(err).WithField("file", name).Error("Error reading the abilities file, keeping its last abilities.")
+			abilities = f.byFile[name]
+		}
+		byFile[name] = abilities
+	}
+
+	names := make([]string, 0, len(byFile))
+	for name := range byFile {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var abilities []model.Ability
+	for _, name := range names {
+		abilities = append(abilities, byFile[name]...)
+	}
+	set := s.newAbilitySet(abilities)
+
+	f.byFile = byFile
+	f.mutex.Lock()
+	f.current = set
+	f.mutex.Unlock()
+
+	logrus.WithField("directory", f.directory).WithField("files", len(byFile)).WithField("abilities", len(abilities)).
+		Info("Loaded the abilities from the files.")
+}
+
+// readAbilitiesFile reads a single ability, or a list of abilities: a JSON array, or an "abilities" array of tables in
+// TOML.
+func readAbilitiesFile(path string, ext string) ([]model.Ability, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var abilities []model.Ability
+	if ext == ".json" {
+		data = bytes.TrimSpace(data)
+		if bytes.HasPrefix(data, []byte("[")) {
+			err = json.Unmarshal(data, &abilities)
+		} else {
+			abilities = make([]model.Ability, 1)
+			err = json.Unmarshal(data, &abilities[0])
+		}
+	} else {
+		v := viper.New()
+		v.SetConfigType("toml")
+		if err = v.ReadConfig(bytes.NewReader(data)); err != nil {
+			return nil, err
+		}
+		if v.IsSet("abilities") {
+			var file struct{ Abilities []model.Ability }
+			err = v.Unmarshal(&file, config.DecodeHook())
+			abilities = file.Abilities
+		} else {
+			abilities = make([]model.Ability, 1)
+			err = v.Unmarshal(&abilities[0], config.DecodeHook())
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range abilities {
+		if abilities[i].Name == "" {
+			return nil, fmt.Errorf("ability #%d without name", i+1)
+		}
+	}
+	return abilities, nil
+}
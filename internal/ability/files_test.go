@@ -0,0 +1,162 @@
+package ability
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+	"github.com/milobella/oratio/pkg/cerebro"
+)
+
+func newFilesTestService() *serviceImpl {
+	return &serviceImpl{
+		health:        newHealthChecker(config.Health{}),
+		breakers:      newBreakers(),
+		limiters:      newLimiters(),
+		loadBalancers: newLoadBalancers(),
+		tlsConfigs:    newTLSConfigs(),
+	}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReloadFileAbilities(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "clock.json"), `{"name": "clock", "intents": ["GET_TIME"], "host": "clock", "port": 10300}`)
+	writeFile(t, filepath.Join(dir, "cinema.toml"), `
+[[abilities]]
+name = "cinema"
+intents = ["LAST_SHOWTIME"]
+url = "https://cinema.example.com"
+required_capabilities = ["screen"]
+`)
+	writeFile(t, filepath.Join(dir, ".hidden.json"), `not even JSON`)
+
+	s := newFilesTestService()
+	f := &fileAbilities{directory: dir, current: s.newAbilitySet(nil), byFile: make(map[string][]model.Ability)}
+	s.reloadFileAbilities(f)
+	if len(f.set().byIntent["GET_TIME"]) != 1 || len(f.set().byIntent["LAST_SHOWTIME"]) != 1 {
+		t.Fatalf("abilities by intent = %v, want clock and cinema", f.set().byIntent)
+	}
+
+	// A file which can't be parsed anymore keeps its last abilities, an invalid URL being rejected as well.
+	writeFile(t, filepath.Join(dir, "clock.json"), `{"name": "clock", "intents": [`)
+	writeFile(t, filepath.Join(dir, "cinema.toml"), `name = "cinema"
+intents = ["LAST_SHOWTIME"]
+url = "ftp://cinema.example.com"
+`)
+	s.reloadFileAbilities(f)
+	if len(f.set().byIntent["GET_TIME"]) != 1 || len(f.set().byIntent["LAST_SHOWTIME"]) != 1 {
+		t.Fatalf("abilities by intent = %v, want clock and cinema kept", f.set().byIntent)
+	}
+
+	// A removed file removes its abilities.
+	if err := os.Remove(filepath.Join(dir, "clock.json")); err != nil {
+		t.Fatal(err)
+	}
+	s.reloadFileAbilities(f)
+	if len(f.set().byIntent["GET_TIME"]) != 0 {
+		t.Errorf("abilities for GET_TIME = %v, want none", f.set().byIntent["GET_TIME"])
+	}
+}
+
+func TestConcurrentReloadFileAbilities(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "clock.json"), `{"name": "clock", "intents": [`)
+
+	s := newFilesTestService()
+	f := &fileAbilities{directory: dir, current: s.newAbilitySet(nil), byFile: make(map[string][]model.Ability)}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.reloadFileAbilities(f)
+			_ = f.set()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestReadAbilitiesFile(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []string
+		wantErr bool
+	}{
+		{"JSON object", "a.json", `{"name": "clock", "intents": ["GET_TIME"]}`, []string{"clock"}, false},
+		{"JSON list", "b.json", `[{"name": "clock"}, {"name": "cinema"}]`, []string{"clock", "cinema"}, false},
+		{"TOML table", "c.toml", "name = \"clock\"\ntimeout = \"2s\"", []string{"clock"}, false},
+		{"TOML list", "d.toml", "[[abilities]]\nname = \"clock\"\n[[abilities]]\nname = \"cinema\"", []string{"clock", "cinema"}, false},
+		{"without name", "e.json", `[{"name": "clock"}, {"intents": ["GET_TIME"]}]`, nil, true},
+		{"invalid", "f.toml", `name = `, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			writeFile(t, path, tt.content)
+			abilities, err := readAbilitiesFile(path, filepath.Ext(tt.file))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readAbilitiesFile() = %v, want an error: %v", err, tt.wantErr)
+			}
+			if len(abilities) != len(tt.want) {
+				t.Fatalf("readAbilitiesFile() = %d abilities, want %d", len(abilities), len(tt.want))
+			}
+			for i, ab := range abilities {
+				if ab.Name != tt.want[i] {
+					t.Errorf("readAbilitiesFile()[%d] = %s, want %s", i, ab.Name, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFileAbilitiesAreNotCached(t *testing.T) {
+	server := newAbilitiesServer(t, map[string]*ability.Response{
+		"clock-v1": ability.NewSimpleResponse("It's noon."),
+		"clock-v2": ability.NewSimpleResponse("It's 12:00."),
+	})
+	dir := t.TempDir()
+	declare := func(path string) {
+		writeFile(t, filepath.Join(dir, "clock.json"), `{"name": "clock", "intents": ["GET_TIME"], "url": "`+server.URL+path+`"}`)
+	}
+	declare("/clock-v1")
+	s := newTestService(config.Abilities{Files: config.Files{Directory: dir}})
+	request := func() string {
+		nlu := cerebro.NLU{BestIntent: "GET_TIME", Intents: []cerebro.Intent{{Label: "GET_TIME", Score: 1}}}
+		return s.RequestAbility(context.Background(), nlu, ability.Context{}, ability.Device{}).Nlg.Sentence
+	}
+
+	if got := request(); got != "It's noon." {
+		t.Fatalf("RequestAbility() = %q, want the ability of the file", got)
+	}
+
+	// The file changing, the next request goes to the new ability.
+	declare("/clock-v2")
+	s.reloadFileAbilities(s.fromFiles)
+	if got := request(); got != "It's 12:00." {
+		t.Fatalf("RequestAbility() after the change = %q, want the changed ability", got)
+	}
+
+	// The file being removed, the ability isn't requested anymore.
+	if err := os.Remove(filepath.Join(dir, "clock.json")); err != nil {
+		t.Fatal(err)
+	}
+	s.reloadFileAbilities(s.fromFiles)
+	if got := request(); got != "I didn't find any ability corresponding to your request." {
+		t.Errorf("RequestAbility() after the removal = %q, want no ability", got)
+	}
+}
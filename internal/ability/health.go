@@ -0,0 +1,116 @@
+package ability
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultHealthEndpoint = "health"
+
+// healthChecker periodically probes the abilities and keeps the health state of every instance.
+// An instance which has never been probed is considered healthy, so that a disabled health checker changes nothing.
+type healthChecker struct {
+	conf   config.Health
+	mutex  sync.RWMutex
+	states map[string]*model.Health
+}
+
+func newHealthChecker(conf config.Health) *healthChecker {
+	if conf.Timeout <= 0 {
+		conf.Timeout = conf.Interval
+	}
+	if conf.Endpoint == "" {
+		conf.Endpoint = defaultHealthEndpoint
+	}
+	if conf.FailureThreshold < 1 {
+		conf.FailureThreshold = 1
+	}
+	return &healthChecker{conf: conf, states: make(map[string]*model.Health)}
+}
+
+// healthTarget is an instance to probe, with the client of its ability requesting it.
+type healthTarget struct {
+	client   *ability.Client
+	instance ability.Instance
+}
+
+// run probes the instances returned by targets at every interval. It never returns.
+func (h *healthChecker) run(targets func() map[string]healthTarget) {
+	ticker := time.NewTicker(h.conf.Interval)
+	defer ticker.Stop()
+	for {
+		h.probe(targets())
+		<-ticker.C
+	}
+}
+
+func (h *healthChecker) probe(targets map[string]healthTarget) {
+	var wg sync.WaitGroup
+	for key, target := range targets {
+		wg.Add(1)
+		go func(key string, target healthTarget) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), h.conf.Timeout)
+			defer cancel()
+			h.record(key, target.client.Name, target.client.CheckInstanceHealth(ctx, h.conf.Endpoint, target.instance))
+		}(key, target)
+	}
+	wg.Wait()
+}
+
+func (h *healthChecker) record(key string, name string, err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	state, ok := h.states[key]
+	if !ok {
+		state = &model.Health{Status: model.HealthStatusUnknown}
+		h.states[key] = state
+	}
+	state.CheckedAt = time.Now()
+
+	if err == nil {
+		if state.Status == model.HealthStatusUnhealthy {
+			logrus.WithField("client", name).WithField("instance", key).Info("The ability is healthy again.")
+		}
+		state.Status = model.HealthStatusHealthy
+		state.Failures = 0
+		state.Error = ""
+		return
+	}
+
+	state.Failures++
+	state.Error = err.Error()
+	if state.Failures >= h.conf.FailureThreshold && state.Status != model.HealthStatusUnhealthy {
+		logrus.WithError(err).WithField("client", name).WithField("instance", key).Warn("The ability is unhealthy.")
+		state.Status = model.HealthStatusUnhealthy
+	}
+}
+
+// state returns a copy of the health state of the instance, or nil if it has never been probed.
+func (h *healthChecker) state(instance model.Instance) *model.Health {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	key := instanceKey(ability.Instance{Host: instance.Host, Port: instance.Port, URL: instance.URL})
+	if state, ok := h.states[key]; ok {
+		result := *state
+		return &result
+	}
+	return nil
+}
+
+func (h *healthChecker) isHealthyInstance(instance ability.Instance) bool {
+	state := h.state(model.Instance{Host: instance.Host, Port: instance.Port, URL: instance.URL})
+	return state == nil || state.Status != model.HealthStatusUnhealthy
+}
+
+// instanceKey identifies the instance by its address, whatever the ability it serves.
+func instanceKey(instance ability.Instance) string {
+	return instance.Address()
+}
@@ -0,0 +1,105 @@
+package ability
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/milobella/oratio/pkg/ability"
+	"github.com/milobella/oratio/pkg/cerebro"
+	"github.com/sirupsen/logrus"
+)
+
+// Hook is run around every call to an ability, to enrich the requests, apply policies or filter the responses.
+type Hook interface {
+	// BeforeCall is run before calling the ability named client. It can modify the request, which is a deep copy
+	// shared with no other call, or short-circuit the call by returning a non-nil response.
+	BeforeCall(client string, request *ability.Request) *ability.Response
+	// AfterCall is run after a successful call to the ability named client. It returns the response to keep, which
+	// can be the given one, modified or not, or a new one.
+	//
+	// A response answered from the response cache goes through the BeforeCall hooks, which can still short-circuit
+	// it, but not through the AfterCall ones: the cached response is the one they returned for the first request.
+	AfterCall(client string, request ability.Request, response *ability.Response) *ability.Response
+}
+
+// callAbility calls the ability through the hooks: their BeforeCall in the registration order, then their AfterCall
+// in the reverse order. A response short-circuiting the call is returned as is, called telling that the ability has
+// not been requested. The response of the ability is validated before the AfterCall hooks.
+func (s *serviceImpl) callAbility(ctx context.Context, client *ability.Client, request ability.Request) (response *ability.Response, called bool, err error) {
+	if len(s.hooks) > 0 {
+		// The concurrent calls of the fan-out share the request, the hooks modifying their own copy of it.
+		request = copyRequest(request)
+	}
+	if response = s.runBeforeCall(client.Name, &request); response != nil {
+		return response, false, nil
+	}
+
+	response, err = client.CallAbilityContext(ctx, request)
+	if err != nil {
+		return nil, true, err
+	}
+	if err = s.validation.check(client.Name, response); err != nil {
+		return nil, true, err
+	}
+
+	for i := len(s.hooks) - 1; i >= 0; i-- {
+		response = s.hooks[i].AfterCall(client.Name, request, response)
+	}
+	return response, true, nil
+}
+
+// beforeCall runs the BeforeCall hooks for a response which doesn't come from a call, a cached one, on a copy of the
+// request. It returns the response of the hook short-circuiting it, if any.
+func (s *serviceImpl) beforeCall(client string, request ability.Request) *ability.Response {
+	if len(s.hooks) == 0 {
+		return nil
+	}
+	request = copyRequest(request)
+	return s.runBeforeCall(client, &request)
+}
+
+// runBeforeCall runs the BeforeCall hooks in the registration order, until one of them short-circuits the call.
+func (s *serviceImpl) runBeforeCall(client string, request *ability.Request) *ability.Response {
+	for _, hook := range s.hooks {
+		if response := hook.BeforeCall(client, request); response != nil {
+			logrus.WithField("client", client).WithField("hook", fmt.Sprintf("%T", hook)).Debug("A hook short-circuited the call.")
+			return response
+		}
+	}
+	return nil
+}
+
+// copyRequest copies the request deeply, down to the free form values of the device state and the slot filling.
+func copyRequest(request ability.Request) ability.Request {
+	request.Nlu.Intents = append([]cerebro.Intent(nil), request.Nlu.Intents...)
+	request.Nlu.Entities = append([]cerebro.Entity(nil), request.Nlu.Entities...)
+	request.Context.SlotFilling = copyValue(request.Context.SlotFilling)
+	request.Device.Capabilities = append([]string(nil), request.Device.Capabilities...)
+	if request.Device.State != nil {
+		request.Device.State = copyValue(request.Device.State).(map[string]interface{})
+	}
+	if request.Device.Instruments != nil {
+		request.Device.Instruments = copyValue(request.Device.Instruments).([]interface{})
+	}
+	return request
+}
+
+// copyValue copies the maps and the slices of a value decoded from JSON, the other values being immutable.
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[key] = copyValue(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = copyValue(item)
+		}
+		return result
+	default:
+		return value
+	}
+}
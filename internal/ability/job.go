@@ -0,0 +1,111 @@
+package ability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/pkg/ability"
+	"github.com/patrickmn/go-cache"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultJobTimeout         = 5 * time.Minute
+	defaultJobAcknowledgement = "I'm on it, I'll get back to you."
+)
+
+var (
+	// ErrUnknownJob is returned when a job is completed while it has never been started, or it has expired.
+	ErrUnknownJob = errors.New("unknown or expired job")
+	// ErrUnauthenticatedJob is returned when a job is completed by a request which is not signed by its ability.
+	ErrUnauthenticatedJob = errors.New("job completion not signed by the ability")
+)
+
+// jobs keeps the jobs started by the abilities until they are completed, or they expire.
+type jobs struct {
+	pending         *cache.Cache
+	acknowledgement string
+}
+
+// job is the request an ability started a job for, kept to deliver the final response to the device, with the client
+// of the ability to authenticate the completion.
+type job struct {
+	client  *ability.Client
+	request ability.Request
+}
+
+func newJobs(conf config.Jobs) *jobs {
+	if conf.Timeout <= 0 {
+		conf.Timeout = defaultJobTimeout
+	}
+	if conf.Acknowledgement == "" {
+		conf.Acknowledgement = defaultJobAcknowledgement
+	}
+	return &jobs{pending: cache.New(conf.Timeout, conf.Timeout), acknowledgement: conf.Acknowledgement}
+}
+
+func jobKey(abilityName string, jobID string) string {
+	return abilityName + "/" + jobID
+}
+
+// startJob keeps the job the ability answered with and returns its response as the acknowledgement, with a default
+// sentence if the ability gave none. The completion of the job being authenticated with the secret shared with the
+// ability, the jobs of the abilities sharing none are not kept.
+func (s *serviceImpl) startJob(client *ability.Client, request ability.Request, response *ability.Response) *ability.Response {
+	logger := logrus.WithField("ability", client.Name).WithField("job", response.Job.ID)
+	if request.Device.ID == "" {
+		logger.Warn("The device has no ID, the final response of the job won't be delivered.")
+	} else if !client.Signed() {
+		logger.Warn("The ability shares no secret, the final response of the job won't be accepted.")
+	} else {
+		s.jobs.pending.SetDefault(jobKey(client.Name, response.Job.ID), &job{client: client, request: request})
+		logger.WithField("device", request.Device.ID).Info("The ability started a job.")
+	}
+	if response.Nlg.Sentence == "" {
+		response.Nlg.Sentence = s.jobs.acknowledgement
+	}
+	return response
+}
+
+// VerifyJob checks that the completion of the job, whose headers and body are given, is signed with the secret shared
+// with its ability.
+func (s *serviceImpl) VerifyJob(abilityName string, jobID string, header http.Header, body []byte) error {
+	item, ok := s.jobs.pending.Get(jobKey(abilityName, jobID))
+	if !ok {
+		return ErrUnknownJob
+	}
+	if err := item.(*job).client.VerifySignature(header.Get(ability.TimestampHeader), header.Get(ability.SignatureHeader), body); err != nil {
+		logrus.WithError(err).WithField("ability", abilityName).WithField("job", jobID).Warn("Rejected a job completion.")
+		return fmt.Errorf("%w: %s", ErrUnauthenticatedJob, err)
+	}
+	return nil
+}
+
+// CompleteJob takes the final response of a job and returns it along with the ID of the device to deliver it to.
+// The response may forward the turn to another ability, as the synchronous ones.
+func (s *serviceImpl) CompleteJob(ctx context.Context, abilityName string, jobID string, response *ability.Response) (string, *ability.Response, error) {
+	key := jobKey(abilityName, jobID)
+	item, ok := s.jobs.pending.Get(key)
+	if !ok {
+		return "", nil, ErrUnknownJob
+	}
+	started := item.(*job)
+	if err := s.validation.check(abilityName, response); err != nil {
+		return "", nil, err
+	}
+	s.jobs.pending.Delete(key)
+
+	logrus.
+		WithField("ability", abilityName).
+		WithField("job", jobID).
+		WithField("device", started.request.Device.ID).
+		Info("The ability completed a job.")
+
+	response.Job = nil
+	response.Context.LastAbility = abilityName
+	return started.request.Device.ID, s.followRedirects(ctx, response, abilityName, started.request), nil
+}
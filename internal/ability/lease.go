@@ -0,0 +1,78 @@
+package ability
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+)
+
+// ErrUnknownLease is returned when a heartbeat renews an ability which is not registered with a lease, or whose lease
+// has expired.
+var ErrUnknownLease = errors.New("unknown or expired lease")
+
+// leases keeps the expiration of the leases of the abilities registered in the database by key (name and version), so
+// that the clients kept in cache stop being requested once their lease expires.
+type leases struct {
+	defaultTTL time.Duration
+	maxTTL     time.Duration
+	mutex      sync.Mutex
+	byKey      map[string]time.Time
+}
+
+func newLeases(conf config.Leases) *leases {
+	return &leases{defaultTTL: conf.DefaultTTL, maxTTL: conf.MaxTTL, byKey: make(map[string]time.Time)}
+}
+
+// grant gives a lease to the ability being registered, with its own TTL or the default one, capped by the maximum one.
+// The ability is registered for good if it has no TTL.
+func (l *leases) grant(ab *model.Ability) {
+	ttl := time.Duration(ab.TTL)
+	if ttl <= 0 {
+		ttl = l.defaultTTL
+	}
+	if l.maxTTL > 0 && ttl > l.maxTTL {
+		ttl = l.maxTTL
+	}
+	if ttl <= 0 {
+		ab.TTL, ab.ExpiresAt = 0, nil
+		return
+	}
+	expiresAt := time.Now().Add(ttl)
+	ab.TTL, ab.ExpiresAt = model.Duration(ttl), &expiresAt
+}
+
+// track remembers the expiration of the lease of the ability, if it has one.
+func (l *leases) track(ab *model.Ability) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if ab.ExpiresAt == nil {
+		delete(l.byKey, ab.Key())
+		return
+	}
+	l.byKey[ab.Key()] = *ab.ExpiresAt
+}
+
+// expired tells whether the lease of the ability behind the client has expired since the client was built.
+func (l *leases) expired(client *ability.Client) bool {
+	ab := model.Ability{Name: client.Name, Version: client.Version}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	expiresAt, ok := l.byKey[ab.Key()]
+	return ok && time.Now().After(expiresAt)
+}
+
+// Renew extends the lease of the ability by its TTL, the ability having to send heartbeats before it expires to stay
+// registered.
+func (s *serviceImpl) Renew(name string, version string) (*model.Ability, error) {
+	result, err := s.dao.Renew(name, version)
+	if err != nil {
+		return nil, err
+	}
+	s.leases.track(result)
+	result.Secret = ""
+	return result, nil
+}
@@ -0,0 +1,41 @@
+package ability
+
+import (
+	"sync"
+	"time"
+
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+)
+
+// limiters keeps the limiter of every ability by key (name and version), so that the calls in flight and their rate
+// are counted across the clients rebuilt from the database.
+type limiters struct {
+	mutex sync.Mutex
+	byKey map[string]limiterEntry
+}
+
+type limiterEntry struct {
+	conf    model.Limits
+	limiter *ability.Limiter
+}
+
+func newLimiters() *limiters {
+	return &limiters{byKey: make(map[string]limiterEntry)}
+}
+
+// get returns the limiter of the ability, a new one if its configuration changed, or nil if it has none.
+func (l *limiters) get(ab *model.Ability) *ability.Limiter {
+	if ab.Limits == nil || (ab.Limits.MaxInFlight <= 0 && ab.Limits.Rate <= 0) {
+		return nil
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if entry, ok := l.byKey[ab.Key()]; ok && entry.conf == *ab.Limits {
+		return entry.limiter
+	}
+	limiter := ability.NewLimiter(ab.Key(), ab.Limits.MaxInFlight, ab.Limits.Rate, ab.Limits.Burst, time.Duration(ab.Limits.QueueTimeout))
+	l.byKey[ab.Key()] = limiterEntry{conf: *ab.Limits, limiter: limiter}
+	return limiter
+}
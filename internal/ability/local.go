@@ -0,0 +1,37 @@
+package ability
+
+import (
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+)
+
+// localClients indexes the clients of the in-process abilities by intent and by name, in the order of their names.
+type localClients = map[string][]*ability.Client
+
+// loadLocalAbilities builds the clients of the in-process abilities registered with ability.Register. They get the
+// default timeout of the configuration, unless their registration gives another one.
+func (s *serviceImpl) loadLocalAbilities(registrations []ability.Registration) {
+	s.localByIntent = make(localClients, len(registrations)*(approximateIntentsByAbility+1))
+	for _, registration := range registrations {
+		var opts []ability.ClientOption
+		if s.timeout > 0 {
+			opts = append(opts, ability.WithTimeout(s.timeout))
+		}
+		client := ability.NewLocalClient(registration.Name, registration.Resolver, append(opts, registration.Options...)...)
+		for _, intent := range registration.Intents {
+			s.localByIntent[intent] = append(s.localByIntent[intent], client)
+		}
+		s.localByIntent[registration.Name] = append(s.localByIntent[registration.Name], client)
+	}
+}
+
+// GetInProcessAbilities fetch the in-process abilities.
+func (s *serviceImpl) GetInProcessAbilities() ([]*model.Ability, error) {
+	abilities := make([]*model.Ability, 0)
+	for intent, intentClients := range s.localByIntent {
+		for _, client := range intentClients {
+			abilities = append(abilities, s.abilityFromClient(client, intent))
+		}
+	}
+	return abilities, nil
+}
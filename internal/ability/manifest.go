@@ -0,0 +1,170 @@
+package ability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultManifestEndpoint = "manifest"
+	defaultManifestTimeout  = 5 * time.Second
+)
+
+// manifests fetches the manifests the abilities describe themselves with.
+type manifests struct {
+	endpoint string
+	timeout  time.Duration
+}
+
+func newManifests(conf config.Manifests, abilitiesTimeout time.Duration) *manifests {
+	m := &manifests{endpoint: conf.Endpoint, timeout: conf.Timeout}
+	if m.endpoint == "" {
+		m.endpoint = defaultManifestEndpoint
+	}
+	if m.timeout <= 0 {
+		m.timeout = abilitiesTimeout
+	}
+	if m.timeout <= 0 {
+		m.timeout = defaultManifestTimeout
+	}
+	return m
+}
+
+// manifestError is returned when an ability registering without intents doesn't serve a valid manifest.
+type manifestError struct {
+	err error
+}
+
+func (e *manifestError) Error() string {
+	return fmt.Sprintf("error fetching the manifest of the ability: %s", e.err)
+}
+
+func (e *manifestError) Unwrap() error {
+	return e.err
+}
+
+// IsManifestError tells whether the error comes from the manifest of an ability registering without intents.
+func IsManifestError(err error) bool {
+	var manifestErr *manifestError
+	return errors.As(err, &manifestErr)
+}
+
+// fetchManifest requests the manifest of the ability at its URL, or its host and port.
+func (s *serviceImpl) fetchManifest(ab *model.Ability) (*ability.Manifest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.manifests.timeout)
+	defer cancel()
+	return s.newClient(ab).FetchManifest(ctx, s.manifests.endpoint)
+}
+
+// applyManifest fills the ability registering without intents from its manifest: its name, its intents and the
+// capabilities it requires, unless they are registered by hand.
+func (s *serviceImpl) applyManifest(ab *model.Ability) error {
+	if len(ab.Intents) > 0 || len(ab.AllInstances()) == 0 {
+		return nil
+	}
+
+	// The credentials are only sent once the registration is accepted, not to whatever URL is being registered.
+	unregistered := *ab
+	unregistered.Credentials = nil
+	manifest, err := s.fetchManifest(&unregistered)
+	if err != nil {
+		return &manifestError{err: err}
+	}
+	if ab.Name != "" && ab.Name != manifest.Name {
+		return &manifestError{err: fmt.Errorf("the manifest names the ability %s instead of %s", manifest.Name, ab.Name)}
+	}
+	ab.Name = manifest.Name
+	ab.Intents = manifest.Intents
+	if len(ab.RequiredCapabilities) == 0 {
+		ab.RequiredCapabilities = manifest.RequiredCapabilities
+	}
+	ab.Manifest = &model.Manifest{
+		ProtocolVersion: manifest.ProtocolVersion,
+		Utterances:      manifest.Utterances,
+		FetchedAt:       time.Now(),
+	}
+	logrus.WithField("ability", ab.Key()).WithField("intents", ab.Intents).Info("Registering the ability from its manifest.")
+	return nil
+}
+
+// refreshManifests fetches the manifests of the abilities registered from them at every interval, and flags the
+// differences with their registration. It never returns.
+func (s *serviceImpl) refreshManifests(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		<-ticker.C
+		abilities, err := s.dao.GetAll()
+		if err != nil {
+			continue
+		}
+		for _, ab := range abilities {
+			if ab.Manifest != nil {
+				s.refreshManifest(ab)
+			}
+		}
+	}
+}
+
+func (s *serviceImpl) refreshManifest(ab *model.Ability) {
+	logger := logrus.WithField("ability", ab.Key())
+	manifest, err := s.fetchManifest(ab)
+	if err != nil {
+		logger.WithError(err).Warn("Error refreshing the manifest of the ability.")
+		return
+	}
+
+	// The utterances are only informative, they are refreshed. The rest is flagged when it drifts, as the registration.
+	refreshed := &model.Manifest{
+		ProtocolVersion: ab.Manifest.ProtocolVersion,
+		Utterances:      manifest.Utterances,
+		FetchedAt:       time.Now(),
+		Drift:           manifestDrift(ab, manifest),
+	}
+	if len(refreshed.Drift) > 0 && strings.Join(refreshed.Drift, "\n") != strings.Join(ab.Manifest.Drift, "\n") {
+		logger.WithField("drift", refreshed.Drift).Warn("The manifest of the ability drifted from its registration.")
+	}
+	_ = s.dao.SetManifest(ab.Name, ab.Version, refreshed)
+}
+
+// manifestDrift lists the differences between the manifest and the registration of the ability.
+func manifestDrift(ab *model.Ability, manifest *ability.Manifest) []string {
+	var drift []string
+	if manifest.Name != ab.Name {
+		drift = append(drift, fmt.Sprintf("name: %s -> %s", ab.Name, manifest.Name))
+	}
+	if !sameSet(ab.Intents, manifest.Intents) {
+		drift = append(drift, fmt.Sprintf("intents: %v -> %v", ab.Intents, manifest.Intents))
+	}
+	if !sameSet(ab.RequiredCapabilities, manifest.RequiredCapabilities) {
+		drift = append(drift, fmt.Sprintf("required_capabilities: %v -> %v", ab.RequiredCapabilities, manifest.RequiredCapabilities))
+	}
+	if manifest.ProtocolVersion != ab.Manifest.ProtocolVersion {
+		drift = append(drift, fmt.Sprintf("protocol_version: %d -> %d", ab.Manifest.ProtocolVersion, manifest.ProtocolVersion))
+	}
+	return drift
+}
+
+func sameSet(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA, sortedB := append([]string(nil), a...), append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
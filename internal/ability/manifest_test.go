@@ -0,0 +1,46 @@
+package ability
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+)
+
+func TestApplyManifestWithoutCredentials(t *testing.T) {
+	t.Setenv("CLOCK_API_KEY", "s3cr3t")
+	var apiKeys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKeys = append(apiKeys, r.Header.Get(defaultAPIKeyHeader))
+		_ = json.NewEncoder(w).Encode(ability.Manifest{Name: "clock", Intents: []string{"GET_TIME"}})
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+
+	s := newFilesTestService()
+	s.manifests = newManifests(config.Manifests{}, 0)
+	s.secrets = newSecrets(map[string]config.Secret{
+		"clock": {Env: "CLOCK_API_KEY", Hosts: []string{serverURL.Hostname()}},
+	})
+	ab := &model.Ability{URL: server.URL, Credentials: &model.Credentials{Type: credentialsAPIKey, Secret: "clock"}}
+
+	if err := s.applyManifest(ab); err != nil {
+		t.Fatalf("applyManifest() = %v", err)
+	}
+	if ab.Name != "clock" || ab.Credentials == nil {
+		t.Errorf("applyManifest() registered %s with credentials %v, want clock with its credentials", ab.Name, ab.Credentials)
+	}
+
+	// Once registered, the manifest refreshes send the credentials.
+	if _, err := s.fetchManifest(ab); err != nil {
+		t.Fatalf("fetchManifest() = %v", err)
+	}
+	if len(apiKeys) != 2 || apiKeys[0] != "" || apiKeys[1] != "s3cr3t" {
+		t.Errorf("API keys sent = %q, want none at the registration and the secret afterwards", apiKeys)
+	}
+}
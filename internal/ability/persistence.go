@@ -2,6 +2,7 @@ package ability
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/milobella/oratio/internal/config"
@@ -16,6 +17,10 @@ type DAO interface {
 	CreateOrUpdate(ability *model.Ability) (*model.Ability, error)
 	GetAll() ([]*model.Ability, error)
 	GetByIntent(intent string) ([]*model.Ability, error)
+	GetByName(name string) ([]*model.Ability, error)
+	GetShadows(name string) ([]*model.Ability, error)
+	Renew(name string, version string) (*model.Ability, error)
+	SetManifest(name string, version string, manifest *model.Manifest) error
 }
 
 type mongoDAO struct {
@@ -28,33 +33,75 @@ type mongoDAO struct {
 
 func NewMongoDAO(conf config.Database, timeout time.Duration) (DAO, error) {
 	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(conf.MongoUrl))
-	return &mongoDAO{
+	dao := &mongoDAO{
 		client:     client,
 		url:        conf.MongoUrl,
 		database:   conf.MongoDatabase,
 		collection: conf.MongoCollection,
 		timeout:    timeout,
-	}, err
+	}
+	if err == nil {
+		dao.ensureLeaseIndex()
+	}
+	return dao, err
+}
+
+// ensureLeaseIndex lets the database remove the abilities whose lease has expired. The removal being periodic, the
+// queries also filter the expired abilities out until then.
+func (dao *mongoDAO) ensureLeaseIndex() {
+	collection := dao.client.Database(dao.database).Collection(dao.collection)
+	ctx, cancel := context.WithTimeout(context.Background(), dao.timeout)
+	defer cancel()
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+	if _, err := collection.Indexes().CreateOne(ctx, index); err != nil {
+		dao.logError(err, "Error creating the index expiring the leases")
+	}
+}
+
+// unexpired matches the abilities registered for good, or whose lease has not expired yet.
+func unexpired() bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{"expires_at": bson.M{"$exists": false}},
+		bson.M{"expires_at": bson.M{"$gt": time.Now()}},
+	}}
+}
+
+// abilityFilter matches the ability having the name and version, a nil version matching the documents without
+// version.
+func abilityFilter(name string, version string) bson.D {
+	var v interface{}
+	if version != "" {
+		v = version
+	}
+	return bson.D{{Key: "name", Value: name}, {Key: "version", Value: v}}
 }
 
+// CreateOrUpdate replaces the ability having the same name and version, an unversioned ability replacing only the
+// unversioned one. Several versions of an ability can then be registered at the same time.
 func (dao *mongoDAO) CreateOrUpdate(ability *model.Ability) (*model.Ability, error) {
 	collection := dao.client.Database(dao.database).Collection(dao.collection)
 
-	opts := options.FindOneAndReplace().SetUpsert(true)
-	ctx, _ := context.WithTimeout(context.Background(), dao.timeout)
-	filter := bson.D{{"name", ability.Name}}
+	opts := options.FindOneAndReplace().SetUpsert(true).SetReturnDocument(options.After)
+	ctx, cancel := context.WithTimeout(context.Background(), dao.timeout)
+	defer cancel()
+	filter := abilityFilter(ability.Name, ability.Version)
 
 	result := collection.FindOneAndReplace(ctx, filter, ability, opts)
 
-	var foundAbility *model.Ability
+	foundAbility := &model.Ability{}
 	err := result.Decode(foundAbility)
 	return foundAbility, err
 }
 
+// GetAll returns the abilities, except the ones whose lease has expired.
 func (dao *mongoDAO) GetAll() ([]*model.Ability, error) {
 	collection := dao.client.Database(dao.database).Collection(dao.collection)
-	ctx, _ := context.WithTimeout(context.Background(), dao.timeout)
-	cursor, err := collection.Find(ctx, bson.D{})
+	ctx, cancel := context.WithTimeout(context.Background(), dao.timeout)
+	defer cancel()
+	cursor, err := collection.Find(ctx, unexpired())
 	if err != nil {
 		dao.logError(err, "Error creating the database cursor")
 		return []*model.Ability{}, err
@@ -67,10 +114,71 @@ func (dao *mongoDAO) GetAll() ([]*model.Ability, error) {
 	return results, nil
 }
 
+// GetByIntent returns the abilities handling the intent, except the shadows which are never requested for the user.
 func (dao *mongoDAO) GetByIntent(intent string) ([]*model.Ability, error) {
+	return dao.find(bson.M{"intents": intent, "shadow_of": bson.M{"$exists": false}})
+}
+
+// GetByName returns the versions of the ability, except the shadows which are never requested for the user.
+func (dao *mongoDAO) GetByName(name string) ([]*model.Ability, error) {
+	return dao.find(bson.M{"name": name, "shadow_of": bson.M{"$exists": false}})
+}
+
+// GetShadows returns the shadows of the ability.
+func (dao *mongoDAO) GetShadows(name string) ([]*model.Ability, error) {
+	return dao.find(bson.M{"shadow_of": name})
+}
+
+// Renew pushes back the expiration of the lease of the ability by its TTL. It fails with ErrUnknownLease if the ability
+// has no lease, or if it has expired.
+func (dao *mongoDAO) Renew(name string, version string) (*model.Ability, error) {
 	collection := dao.client.Database(dao.database).Collection(dao.collection)
-	ctx, _ := context.WithTimeout(context.Background(), dao.timeout)
-	cursor, err := collection.Find(ctx, bson.M{"intents": intent})
+	ctx, cancel := context.WithTimeout(context.Background(), dao.timeout)
+	defer cancel()
+	filter := append(abilityFilter(name, version), bson.E{Key: "ttl", Value: bson.M{"$exists": true}})
+	filter = append(filter, bson.E{Key: "expires_at", Value: bson.M{"$gt": time.Now()}})
+
+	leased := &model.Ability{}
+	if err := collection.FindOne(ctx, filter).Decode(leased); errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrUnknownLease
+	} else if err != nil {
+		dao.logError(err, "Error finding the ability to renew")
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(time.Duration(leased.TTL))
+	update := bson.M{"$set": bson.M{"expires_at": expiresAt}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	renewed := &model.Ability{}
+	if err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(renewed); errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrUnknownLease
+	} else if err != nil {
+		dao.logError(err, "Error renewing the lease of the ability")
+		return nil, err
+	}
+	return renewed, nil
+}
+
+// SetManifest replaces the manifest of the ability, leaving the rest of its registration untouched.
+func (dao *mongoDAO) SetManifest(name string, version string, manifest *model.Manifest) error {
+	collection := dao.client.Database(dao.database).Collection(dao.collection)
+	ctx, cancel := context.WithTimeout(context.Background(), dao.timeout)
+	defer cancel()
+	_, err := collection.UpdateOne(ctx, abilityFilter(name, version), bson.M{"$set": bson.M{"manifest": manifest}})
+	if err != nil {
+		dao.logError(err, "Error updating the manifest of the ability")
+	}
+	return err
+}
+
+func (dao *mongoDAO) find(filter bson.M) ([]*model.Ability, error) {
+	collection := dao.client.Database(dao.database).Collection(dao.collection)
+	ctx, cancel := context.WithTimeout(context.Background(), dao.timeout)
+	defer cancel()
+	for key, value := range unexpired() {
+		filter[key] = value
+	}
+	cursor, err := collection.Find(ctx, filter)
 	if err != nil {
 		dao.logError(err, "Error creating the database cursor")
 		return []*model.Ability{}, err
@@ -0,0 +1,66 @@
+package ability
+
+import (
+	"context"
+
+	"github.com/milobella/oratio/pkg/ability"
+	"github.com/milobella/oratio/pkg/cerebro"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultMaxRedirects = 3
+
+// followRedirects forwards the turn as long as the abilities redirect it. To avoid loops, the number of redirects is
+// limited and an ability can't be requested twice in the same turn. The turn stops at an ability starting a job.
+func (s *serviceImpl) followRedirects(ctx context.Context, response *ability.Response, from string, request ability.Request) *ability.Response {
+	visited := map[string]bool{from: true}
+	for redirects := 0; response.Redirect != nil; redirects++ {
+		redirect := response.Redirect
+		logger := logrus.WithField("from", from).WithField("to", redirect.Target())
+		if redirects >= s.maxRedirects {
+			logger.WithField("maxRedirects", s.maxRedirects).Error("Too many redirects, stopping the turn.")
+			return ability.NewSimpleResponse("I got lost handling your request, sorry.")
+		}
+
+		request = redirectRequest(request, response)
+		if builtinResponse, ok := s.builtinResponse(redirect.Target(), request); ok {
+			logger.Debug("Redirected to a built-in intent.")
+			return builtinResponse
+		}
+
+		client, _, err := s.resolveClient(ctx, redirect.Target(), request.Device)
+		if err != nil {
+			return errorResponse(err)
+		}
+		if visited[client.Name] {
+			logger.WithField("client", client.Name).Error("Redirect loop detected, stopping the turn.")
+			return ability.NewSimpleResponse("I got lost handling your request, sorry.")
+		}
+		visited[client.Name] = true
+
+		logger.WithField("client", client.Name).Debug("Following the redirect.")
+		if response, _, err = s.callAbility(ctx, client, request); err != nil {
+			return errorResponse(err)
+		}
+		response.Context.LastAbility = client.Name
+		// The ability redirected to may be working on a long-running job, as the first one.
+		if response.Job != nil {
+			return s.startJob(client, request, response)
+		}
+		from = client.Name
+	}
+	return response
+}
+
+// redirectRequest builds the request forwarded by a redirecting response: its entities are injected in the NLU, its
+// intent replaces the NLU ones and its context replaces the request's one.
+func redirectRequest(request ability.Request, response *ability.Response) ability.Request {
+	redirect := response.Redirect
+	nlu := request.Nlu
+	nlu.Entities = append(append([]cerebro.Entity{}, nlu.Entities...), redirect.Entities...)
+	if redirect.Intent != "" {
+		nlu.BestIntent = redirect.Intent
+		nlu.Intents = []cerebro.Intent{{Label: redirect.Intent, Score: 1}}
+	}
+	return ability.Request{Nlu: nlu, Context: response.Context, Device: request.Device}
+}
@@ -0,0 +1,72 @@
+package ability
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+)
+
+// newAbilitiesServer serves the responses of the abilities, each one at the path of its name.
+func newAbilitiesServer(t *testing.T, responses map[string]*ability.Response) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/"), "/resolve")
+		_ = json.NewEncoder(w).Encode(responses[name])
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func redirectTo(name string) *ability.Response {
+	return &ability.Response{Redirect: &ability.Redirect{Ability: name}}
+}
+
+func TestFollowRedirects(t *testing.T) {
+	server := newAbilitiesServer(t, map[string]*ability.Response{
+		"booking": ability.NewSimpleResponse("Booked."),
+		"loop-a":  redirectTo("loop-b"),
+		"loop-b":  redirectTo("loop-a"),
+		"chain-1": redirectTo("chain-2"),
+		"chain-2": redirectTo("chain-3"),
+		"chain-3": ability.NewSimpleResponse("End of the chain."),
+	})
+	registered := func(name string, intents ...string) *model.Ability {
+		return &model.Ability{Name: name, Intents: intents, URL: server.URL + "/" + name}
+	}
+	// The abilities are registered over the API, the redirects resolving them by name from the database.
+	s := newTestService(config.Abilities{MaxRedirects: 2},
+		registered("booking", "BOOK_TICKET"),
+		registered("loop-a"), registered("loop-b"),
+		registered("chain-1"), registered("chain-2"), registered("chain-3"),
+	)
+
+	tests := []struct {
+		name     string
+		from     string
+		redirect *ability.Redirect
+		want     string
+	}{
+		{"to an ability", "cinema", &ability.Redirect{Ability: "booking"}, "Booked."},
+		{"to an intent", "cinema", &ability.Redirect{Intent: "BOOK_TICKET"}, "Booked."},
+		{"ability taking precedence", "cinema", &ability.Redirect{Ability: "booking", Intent: "UNKNOWN"}, "Booked."},
+		{"unknown ability", "cinema", &ability.Redirect{Ability: "unknown"}, "I didn't find any ability corresponding to your request."},
+		{"loop", "cinema", &ability.Redirect{Ability: "loop-a"}, "I got lost handling your request, sorry."},
+		{"back to the first ability", "booking", &ability.Redirect{Ability: "booking"}, "I got lost handling your request, sorry."},
+		{"within the limit", "chain-1", &ability.Redirect{Ability: "chain-2"}, "End of the chain."},
+		{"over the limit", "cinema", &ability.Redirect{Ability: "chain-1"}, "I got lost handling your request, sorry."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := s.followRedirects(context.Background(), &ability.Response{Redirect: tt.redirect}, tt.from, ability.Request{})
+			if response.Nlg.Sentence != tt.want {
+				t.Errorf("followRedirects() = %q, want %q", response.Nlg.Sentence, tt.want)
+			}
+		})
+	}
+}
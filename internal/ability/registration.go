@@ -0,0 +1,48 @@
+package ability
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+)
+
+// invalidAbilityError is returned when an ability is registered with a configuration it can't be requested with.
+type invalidAbilityError struct {
+	ability string
+	err     error
+}
+
+func (e *invalidAbilityError) Error() string {
+	return fmt.Sprintf("invalid ability %s: %s", e.ability, e.err)
+}
+
+func (e *invalidAbilityError) Unwrap() error {
+	return e.err
+}
+
+// IsInvalidAbility tells whether the error comes from an ability registered with an invalid configuration.
+func IsInvalidAbility(err error) bool {
+	var invalidErr *invalidAbilityError
+	return errors.As(err, &invalidErr)
+}
+
+// validateAbility checks the configuration of an ability before it is registered, in the database or from a file.
+func (s *serviceImpl) validateAbility(ab *model.Ability) error {
+	for _, i := range ab.AllInstances() {
+		if i.URL == "" {
+			continue
+		}
+		if err := ability.ValidateURL(i.URL); err != nil {
+			return &invalidAbilityError{ability: ab.Key(), err: err}
+		}
+	}
+	if _, err := s.credentialsOption(ab); err != nil {
+		return &invalidAbilityError{ability: ab.Key(), err: err}
+	}
+	if _, err := s.tlsConfigs.get(ab); err != nil {
+		return &invalidAbilityError{ability: ab.Key(), err: fmt.Errorf("loading the TLS configuration: %w", err)}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package ability
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/pkg/ability"
+	"github.com/patrickmn/go-cache"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultResponseCacheCleanupInterval = time.Minute
+
+// responseCache keeps the responses the abilities marked as cacheable, to answer the identical requests without
+// requesting the abilities. The keys of the device state selected by the last cacheable response of every intent are
+// kept aside, as they are needed to build the key of the next requests.
+type responseCache struct {
+	responses *cache.Cache
+	maxTTL    time.Duration
+	mutex     sync.RWMutex
+	stateKeys map[string][]string
+}
+
+// cachedResponse is a cached response, with the name of the ability which answered it.
+type cachedResponse struct {
+	ability  string
+	response ability.Response
+}
+
+func newResponseCache(conf config.ResponseCache) *responseCache {
+	if conf.CleanupInterval <= 0 {
+		conf.CleanupInterval = defaultResponseCacheCleanupInterval
+	}
+	return &responseCache{
+		responses: cache.New(cache.NoExpiration, conf.CleanupInterval),
+		maxTTL:    conf.MaxTTL,
+		stateKeys: make(map[string][]string),
+	}
+}
+
+// get returns a copy of the cached response to the request for the intent, with the name of the ability which
+// answered it, or nil if there is none.
+func (c *responseCache) get(intent string, request ability.Request) (*ability.Response, string) {
+	c.mutex.RLock()
+	stateKeys, ok := c.stateKeys[intent]
+	c.mutex.RUnlock()
+	if !ok {
+		return nil, ""
+	}
+	key := responseCacheKey(intent, stateKeys, request)
+	if item, found := c.responses.Get(key); found {
+		logrus.WithField("intent", intent).WithField("key", key).Debug("Found the response in the response cache.")
+		cached := item.(*cachedResponse)
+		response := cached.response
+		return &response, cached.ability
+	}
+	return nil, ""
+}
+
+// add keeps the response of the ability to the request for the intent, if the ability marked it as cacheable. The
+// responses acknowledging a job or forwarding the turn are never cached.
+func (c *responseCache) add(intent string, abilityName string, request ability.Request, response *ability.Response) {
+	if response.Cache == nil || response.Cache.TTL <= 0 || response.Job != nil || response.Redirect != nil {
+		return
+	}
+	ttl := time.Duration(response.Cache.TTL) * time.Second
+	if c.maxTTL > 0 && ttl > c.maxTTL {
+		ttl = c.maxTTL
+	}
+	stateKeys := append([]string(nil), response.Cache.State...)
+	sort.Strings(stateKeys)
+
+	c.mutex.Lock()
+	c.stateKeys[intent] = stateKeys
+	c.mutex.Unlock()
+
+	c.responses.Set(responseCacheKey(intent, stateKeys, request), &cachedResponse{ability: abilityName, response: *response}, ttl)
+}
+
+// cachedResponse returns the response cached for the intent, if any. The policies of the hooks apply to the cached
+// responses as to the calls, their BeforeCall being run.
+func (s *serviceImpl) cachedResponse(intent string, request ability.Request) *ability.Response {
+	response, abilityName := s.responseCache.get(intent, request)
+	if response == nil {
+		return nil
+	}
+	if hookResponse := s.beforeCall(abilityName, request); hookResponse != nil {
+		return hookResponse
+	}
+	return response
+}
+
+// responseCacheKey identifies the requests which get the same response: same intent, entities, device capabilities,
+// values of the selected keys of the device state and, during a slot filling, same context, so that a step of a
+// conversation is never answered with the response of another step.
+func responseCacheKey(intent string, stateKeys []string, request ability.Request) string {
+	entities := make([]string, 0, len(request.Nlu.Entities))
+	for _, entity := range request.Nlu.Entities {
+		entities = append(entities, entity.Label+"="+entity.Text)
+	}
+	sort.Strings(entities)
+
+	capabilities := append([]string(nil), request.Device.Capabilities...)
+	sort.Strings(capabilities)
+
+	state := make([]string, 0, len(stateKeys))
+	for _, key := range stateKeys {
+		value, _ := json.Marshal(request.Device.State[key])
+		state = append(state, key+"="+string(value))
+	}
+
+	var context []byte
+	if request.Context.SlotFilling != nil {
+		context, _ = json.Marshal(request.Context)
+	}
+
+	return strings.Join([]string{
+		intent,
+		strings.Join(entities, "&"),
+		strings.Join(capabilities, "&"),
+		strings.Join(state, "&"),
+		string(context),
+	}, "|")
+}
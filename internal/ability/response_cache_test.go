@@ -0,0 +1,123 @@
+package ability
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+	"github.com/milobella/oratio/pkg/cerebro"
+)
+
+func TestResponseCache(t *testing.T) {
+	request := ability.Request{
+		Nlu:    cerebro.NLU{Entities: []cerebro.Entity{{Label: "city", Text: "Paris"}}},
+		Device: ability.Device{State: map[string]interface{}{"city": "Paris", "volume": 10}},
+	}
+	cached := &ability.Response{
+		Nlg:   ability.NewSimpleResponse("Dune is on at 20:30").Nlg,
+		Cache: &ability.CachePolicy{TTL: 60, State: []string{"city"}},
+	}
+
+	tests := []struct {
+		name    string
+		request func(ability.Request) ability.Request
+		hit     bool
+	}{
+		{"same request", func(r ability.Request) ability.Request { return r }, true},
+		{"other unselected state", func(r ability.Request) ability.Request {
+			r.Device.State = map[string]interface{}{"city": "Paris", "volume": 5}
+			return r
+		}, true},
+		{"other last ability", func(r ability.Request) ability.Request {
+			r.Context.LastAbility = "clock"
+			return r
+		}, true},
+		{"other selected state", func(r ability.Request) ability.Request {
+			r.Device.State = map[string]interface{}{"city": "Lyon"}
+			return r
+		}, false},
+		{"other entities", func(r ability.Request) ability.Request {
+			r.Nlu.Entities = []cerebro.Entity{{Label: "city", Text: "Lyon"}}
+			return r
+		}, false},
+		{"slot filling", func(r ability.Request) ability.Request {
+			r.Context = ability.Context{LastAbility: "cinema", SlotFilling: map[string]interface{}{"showtime": "20:30"}}
+			return r
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newResponseCache(config.ResponseCache{})
+			c.add("LAST_SHOWTIME", "cinema", request, cached)
+			response, abilityName := c.get("LAST_SHOWTIME", tt.request(request))
+			if (response != nil) != tt.hit {
+				t.Fatalf("get() = %v, want a hit: %v", response, tt.hit)
+			}
+			if tt.hit && abilityName != "cinema" {
+				t.Errorf("get() answered by %q, want cinema", abilityName)
+			}
+		})
+	}
+}
+
+func TestResponseCacheNotCacheable(t *testing.T) {
+	tests := []struct {
+		name     string
+		response *ability.Response
+	}{
+		{"no cache policy", &ability.Response{}},
+		{"no TTL", &ability.Response{Cache: &ability.CachePolicy{}}},
+		{"job", &ability.Response{Cache: &ability.CachePolicy{TTL: 60}, Job: &ability.Job{ID: "1"}}},
+		{"redirect", &ability.Response{Cache: &ability.CachePolicy{TTL: 60}, Redirect: &ability.Redirect{Intent: "GET_TIME"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newResponseCache(config.ResponseCache{})
+			c.add("LAST_SHOWTIME", "cinema", ability.Request{}, tt.response)
+			if response, _ := c.get("LAST_SHOWTIME", ability.Request{}); response != nil {
+				t.Errorf("get() = %v, want no cached response", response)
+			}
+		})
+	}
+}
+
+func TestResponseCacheOnTheFallbackIntent(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_ = json.NewEncoder(w).Encode(&ability.Response{
+			Nlg:   ability.NewSimpleResponse("It is noon.").Nlg,
+			Cache: &ability.CachePolicy{TTL: 60},
+		})
+	}))
+	defer server.Close()
+	s := newTestService(config.Abilities{}, &model.Ability{Name: "clock", Intents: []string{"GET_TIME"}, URL: server.URL})
+
+	tests := []struct {
+		name    string
+		intents []cerebro.Intent
+	}{
+		// The best intent has no ability, the response of the next one being cached for it.
+		{"fallback intent", []cerebro.Intent{{Label: "UNKNOWN", Score: 0.9}, {Label: "GET_TIME", Score: 0.8}}},
+		{"best intent", []cerebro.Intent{{Label: "GET_TIME", Score: 0.9}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 3; i++ {
+				response := s.RequestAbility(context.Background(), cerebro.NLU{Intents: tt.intents}, ability.Context{}, ability.Device{})
+				if response.Nlg.Sentence != "It is noon." {
+					t.Fatalf("RequestAbility() = %q, want the response of clock", response.Nlg.Sentence)
+				}
+			}
+		})
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("clock has been called %d times, want 1, the next requests being answered from the cache", got)
+	}
+}
@@ -1,7 +1,13 @@
 package ability
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/milobella/oratio/internal/config"
 	"github.com/milobella/oratio/internal/model"
@@ -9,99 +15,409 @@ import (
 	"github.com/milobella/oratio/pkg/cerebro"
 	"github.com/patrickmn/go-cache"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// errNoAbility is returned when no ability handles the intent or the ability name.
+var errNoAbility = errors.New("no ability found")
+
+// unsupportedDeviceError is returned when the abilities handling the intent require capabilities the device lacks.
+type unsupportedDeviceError struct {
+	ability string
+	missing []string
+}
+
+func (e *unsupportedDeviceError) Error() string {
+	return fmt.Sprintf("the device lacks the capabilities %s required by %s", strings.Join(e.missing, ", "), e.ability)
+}
+
 // Used to compute an approximate size of the map that will welcome the clients (one client by ability and by intent)
 const approximateIntentsByAbility = 3
 
 type Service interface {
-	RequestAbility(nlu cerebro.NLU, context ability.Context, device ability.Device) *ability.Response
+	RequestAbility(ctx context.Context, nlu cerebro.NLU, abilityContext ability.Context, device ability.Device) *ability.Response
 	GetCacheAbilities() ([]*model.Ability, error)
 	GetDatabaseAbilities() ([]*model.Ability, error)
 	GetConfigAbilities() ([]*model.Ability, error)
+	GetFilesAbilities() ([]*model.Ability, error)
+	GetInProcessAbilities() ([]*model.Ability, error)
 	GetAllAbilities() (*model.Abilities, error)
 	CreateOrUpdate(ability *model.Ability) (*model.Ability, error)
+	Renew(name string, version string) (*model.Ability, error)
+	GetShadowDiffs(abilityName string, shadowName string) []*model.ShadowDiff
+	VerifyJob(abilityName string, jobID string, header http.Header, body []byte) error
+	CompleteJob(ctx context.Context, abilityName string, jobID string, response *ability.Response) (string, *ability.Response, error)
 }
 
 // clients is used to store and index clients computed from abilities. It is used only for abilities coming
-// from configuration because cache and database have their own indexation.
+// from configuration and files because cache and database have their own indexation.
 // Moreover, we don't want to bump all clients in the memory. We build clients from database data in a lazy mode.
 // Configuration is just here in a last resort, if database is not accessible for example.
+// The clients are indexed by ability key (name and version), the abilities being indexed by intent and name aside.
 type clients = map[string]*ability.Client
 
-func newClients(configAbilities []model.Ability) clients {
-	clientsMap := make(map[string]*ability.Client, len(configAbilities)*(approximateIntentsByAbility+1))
-	for _, ab := range configAbilities {
-		client := ability.NewClient(ab.Host, ab.Port, ab.Name)
+// abilitiesIndex indexes the abilities from configuration by intent and by name. Several abilities can handle the
+// same intent, the first declared being the preferred one and the next ones being alternatives (for the devices
+// lacking the capabilities required by the first one for example), or other versions of the same ability.
+type abilitiesIndex = map[string][]*model.Ability
+
+// abilitySet is a set of abilities known upfront (from configuration or files), with their clients built once.
+type abilitySet struct {
+	clients  clients
+	byIntent abilitiesIndex
+	// shadows are indexed by the name of their ability, as they are never requested for the user.
+	shadows abilitiesIndex
+}
+
+// newAbilitySet builds the clients of the abilities and indexes them.
+func (s *serviceImpl) newAbilitySet(abilities []model.Ability) *abilitySet {
+	set := &abilitySet{
+		clients:  make(clients, len(abilities)),
+		byIntent: make(abilitiesIndex, len(abilities)*(approximateIntentsByAbility+1)),
+		shadows:  make(abilitiesIndex),
+	}
+	for i := range abilities {
+		ab := &abilities[i]
+		set.clients[ab.Key()] = s.newClient(ab)
+		if ab.ShadowOf != "" {
+			set.shadows[ab.ShadowOf] = append(set.shadows[ab.ShadowOf], ab)
+			continue
+		}
 		for _, intent := range ab.Intents {
-			clientsMap[intent] = client
+			set.byIntent[intent] = append(set.byIntent[intent], ab)
+		}
+		set.byIntent[ab.Name] = append(set.byIntent[ab.Name], ab)
+	}
+	return set
+}
+
+// describe lists the abilities of the set by intent, with the health of their instances.
+func (s *serviceImpl) describe(set *abilitySet) []*model.Ability {
+	abilities := make([]*model.Ability, 0)
+	for intent, intentAbilities := range set.byIntent {
+		for _, ab := range intentAbilities {
+			result := s.abilityFromClient(set.clients[ab.Key()], intent)
+			result.Traffic = ab.Traffic
+			abilities = append(abilities, result)
+		}
+	}
+	return abilities
+}
+
+// newClient builds the client of an ability, balancing the calls between its healthy instances, retrying them with its
+// own timeout and sharing its load balancer, its circuit breaker and its limiter with the other clients of the same
+// ability.
+func (s *serviceImpl) newClient(ab *model.Ability) *ability.Client {
+	instances := make([]ability.Instance, 0, len(ab.Instances)+1)
+	for _, i := range ab.AllInstances() {
+		instances = append(instances, ability.Instance{Host: i.Host, Port: i.Port, URL: i.URL, Weight: i.Weight})
+	}
+	opts := []ability.ClientOption{
+		ability.WithLoadBalancer(s.loadBalancers.get(ab)),
+		ability.WithInstanceFilter(s.health.isHealthyInstance),
+		ability.WithRequiredCapabilities(ab.RequiredCapabilities...),
+		ability.WithVersion(ab.Version),
+		ability.WithProtocol(ability.Protocol(ab.Protocol)),
+	}
+	if ab.Secret != "" {
+		opts = append(opts, ability.WithSigningSecret([]byte(ab.Secret)))
+	}
+	if len(ab.Headers) > 0 {
+		opts = append(opts, ability.WithHeaders(ab.Headers))
+	}
+	if credentials, err := s.credentialsOption(ab); err != nil {
+		opts = append(opts, ability.WithConfigurationError(err))
+	} else if credentials != nil {
+		opts = append(opts, credentials)
+	}
+	// The client of an ability whose TLS configuration can't be loaded is unavailable, rather than falling back on the
+	// system CAs and no client certificate.
+	if tlsConfig, err := s.tlsConfigs.get(ab); err != nil {
+		opts = append(opts, ability.WithConfigurationError(fmt.Errorf("loading the TLS configuration: %w", err)))
+	} else if tlsConfig != nil {
+		opts = append(opts, ability.WithTLSConfig(tlsConfig))
+	}
+	if timeout := time.Duration(ab.Timeout); timeout > 0 {
+		opts = append(opts, ability.WithTimeout(timeout))
+	} else if s.timeout > 0 {
+		opts = append(opts, ability.WithTimeout(s.timeout))
+	}
+	if ab.Retry != nil {
+		opts = append(opts, ability.WithRetryPolicy(ability.RetryPolicy{
+			MaxRetries: ab.Retry.MaxRetries,
+			Backoff:    ability.Backoff(ab.Retry.Backoff),
+			Delay:      time.Duration(ab.Retry.Delay),
+			MaxDelay:   time.Duration(ab.Retry.MaxDelay),
+		}))
+	}
+	if breaker := s.breakers.get(ab); breaker != nil {
+		opts = append(opts, ability.WithCircuitBreaker(breaker))
+	}
+	if limiter := s.limiters.get(ab); limiter != nil {
+		opts = append(opts, ability.WithLimiter(limiter))
+	}
+	return ability.NewBalancedClient(ab.Name, instances, opts...)
+}
+
+// abilityFromClient describes the ability behind a client for the given intent, with the health of its instances.
+func (s *serviceImpl) abilityFromClient(client *ability.Client, intent string) *model.Ability {
+	result := &model.Ability{
+		Name:                 client.Name,
+		Version:              client.Version,
+		Intents:              []string{intent},
+		Protocol:             string(client.Protocol()),
+		RequiredCapabilities: client.RequiredCapabilities(),
+		BreakerState:         string(client.BreakerState()),
+	}
+	for index, i := range client.Instances() {
+		instance := model.Instance{Host: i.Host, Port: i.Port, URL: i.URL, Weight: i.Weight}
+		if index == 0 {
+			result.Host = i.Host
+			result.Port = i.Port
+			result.URL = i.URL
+			result.Health = s.health.state(instance)
+			continue
 		}
-		clientsMap[client.Name] = client
+		instance.Health = s.health.state(instance)
+		result.Instances = append(result.Instances, instance)
 	}
-	return clientsMap
+	return result
 }
 
 type serviceImpl struct {
-	dao               DAO
-	clientsCache      *cache.Cache
-	clientsFromConfig clients
-	stopIntent        string
+	dao           DAO
+	clientsCache  *cache.Cache
+	shadowsCache  *cache.Cache
+	fromConfig    *abilitySet
+	fromFiles     *fileAbilities
+	localByIntent localClients
+	shadowDiffs   *shadowDiffs
+	stopIntent    string
+	minScore      float32
+	fanOutMargin  float32
+	arbitration   arbitration
+	health        *healthChecker
+	breakers      *breakers
+	limiters      *limiters
+	loadBalancers *loadBalancers
+	leases        *leases
+	manifests     *manifests
+	validation    validation
+	tlsConfigs    *tlsConfigs
+	secrets       secrets
+	timeout       time.Duration
+	builtins      builtins
+	maxRedirects  int
+	jobs          *jobs
+	responseCache *responseCache
+	hooks         []Hook
 }
 
-func NewService(dao DAO, conf config.Abilities) Service {
-	return &serviceImpl{
-		dao:               dao,
-		clientsCache:      cache.New(conf.Cache.Expiration, conf.Cache.CleanupInterval),
-		clientsFromConfig: newClients(conf.List),
-		stopIntent:        conf.StopIntent,
+// NewService builds the ability service. The hooks are run around every call to an ability, in the given order.
+func NewService(dao DAO, conf config.Abilities, hooks ...Hook) Service {
+	s := &serviceImpl{
+		dao:           dao,
+		clientsCache:  cache.New(conf.Cache.Expiration, conf.Cache.CleanupInterval),
+		shadowsCache:  cache.New(conf.Cache.Expiration, conf.Cache.CleanupInterval),
+		stopIntent:    conf.StopIntent,
+		minScore:      conf.MinScore,
+		fanOutMargin:  conf.FanOut.Margin,
+		arbitration:   newArbitration(conf.FanOut.Arbitration),
+		health:        newHealthChecker(conf.Health),
+		breakers:      newBreakers(),
+		limiters:      newLimiters(),
+		loadBalancers: newLoadBalancers(),
+		leases:        newLeases(conf.Leases),
+		manifests:     newManifests(conf.Manifests, conf.Timeout),
+		validation:    newValidation(conf.Validation),
+		tlsConfigs:    newTLSConfigs(),
+		secrets:       newSecrets(conf.Secrets),
+		timeout:       conf.Timeout,
+		builtins:      newBuiltins(conf.Builtins),
+		maxRedirects:  conf.MaxRedirects,
+		jobs:          newJobs(conf.Jobs),
+		responseCache: newResponseCache(conf.ResponseCache),
+		hooks:         hooks,
+		shadowDiffs:   newShadowDiffs(conf.Shadow.MaxDiffs),
+	}
+	if s.maxRedirects <= 0 {
+		s.maxRedirects = defaultMaxRedirects
+	}
+	s.fromConfig = s.newAbilitySet(conf.List)
+	s.fromFiles = s.watchFileAbilities(conf.Files)
+	s.loadLocalAbilities(ability.Registered())
+	if conf.Health.Interval > 0 {
+		go s.health.run(s.healthTargets)
+	}
+	if conf.Manifests.RefreshInterval > 0 {
+		go s.refreshManifests(conf.Manifests.RefreshInterval)
 	}
+	return s
 }
 
-// getBestIntentOrAbility computes the best intent or ability from the nlu but also from the context.
-// Indeed, if the context says that a slot filling mechanism is in place, we force the future request to be sent to
+// getRankedIntentsOrAbility computes the intents or ability to request from the nlu but also from the context.
+// Intents scoring below the configured minimum score are discarded and the remaining ones are ranked from the best
+// score to the worst, so that the caller can fall back on the next intent if the best one has no ability.
+// If the context says that a slot filling mechanism is in place, we force the future request to be sent to
 // last ability. Except if the best intent is the stop intent, in which case we return the stop intent and everything
 // will be stopped.
-func (s *serviceImpl) getBestIntentOrAbility(nlu cerebro.NLU, ctx ability.Context) string {
-	var bestIntent string
-	if len(nlu.Intents) != 0 && nlu.BestIntent != "" {
-		bestIntent = nlu.BestIntent
+func (s *serviceImpl) getRankedIntentsOrAbility(nlu cerebro.NLU, ctx ability.Context) []cerebro.Intent {
+	ranked := make([]cerebro.Intent, 0, len(nlu.Intents))
+	for _, intent := range nlu.Intents {
+		if intent.Label != "" && intent.Score >= s.minScore {
+			ranked = append(ranked, intent)
+		}
 	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
 
-	if ctx.SlotFilling == nil || bestIntent == s.stopIntent {
-		return bestIntent
+	if ctx.SlotFilling == nil || (len(ranked) > 0 && ranked[0].Label == s.stopIntent) {
+		return ranked
 	}
 
-	return ctx.LastAbility
+	return []cerebro.Intent{{Label: ctx.LastAbility}}
 }
 
 // RequestAbility Call ability corresponding to the intent resolved by cerebro.
-func (s *serviceImpl) RequestAbility(nlu cerebro.NLU, ctx ability.Context, device ability.Device) *ability.Response {
+// The intents are tried from the best to the worst score until one of them resolves to an ability. When several
+// intents score close together, their abilities are all requested and the winning response is arbitrated.
+func (s *serviceImpl) RequestAbility(ctx context.Context, nlu cerebro.NLU, abilityContext ability.Context, device ability.Device) *ability.Response {
 
-	intentOrAbility := s.getBestIntentOrAbility(nlu, ctx)
+	intentsOrAbility := s.getRankedIntentsOrAbility(nlu, abilityContext)
+	if len(intentsOrAbility) == 0 {
+		logrus.
+			WithField("text", nlu.Text).
+			WithField("minScore", s.minScore).
+			Info("No intent reached the minimum score.")
+		return ability.NewSimpleResponse("I didn't understand your request.")
+	}
 
-	// TODO put personal request in anima
-	if intentOrAbility == "HELLO" {
-		return ability.NewSimpleResponse("Hello")
+	request := ability.Request{Nlu: nlu, Context: abilityContext, Device: device}
+	candidates, response := s.resolveCandidates(ctx, intentsOrAbility, request)
+	if response != nil {
+		return response
 	}
 
-	if intentOrAbility == s.stopIntent {
-		return ability.NewSimpleResponse("")
+	if len(candidates) == 0 {
+		return ability.NewSimpleResponse("I didn't find any ability corresponding to your request.")
 	}
 
-	if client, ok := s.resolveClient(intentOrAbility); ok {
-		if response, err := client.CallAbility(ability.Request{Nlu: nlu, Context: ctx, Device: device}); err == nil {
-			// The call to the ability is a success.
+	winner, result, err := s.callCandidates(ctx, candidates, request)
+	if err == nil {
+		response = result.response
+		// The call to the ability is a success.
 
-			// Then we update the cache, only if not already existing.
-			// If we always set the client in the cache, it would never expire.
-			_ = s.clientsCache.Add(intentOrAbility, client, cache.DefaultExpiration)
-			// And we make sure the response contains the last ability used.
-			response.Context.LastAbility = client.Name
-			return response
+		// Then we update the cache, only if not already existing.
+		// If we always set the client in the cache, it would never expire.
+		// The abilities having several versions are not cached, their traffic being split between them at each request.
+		if winner.cacheable {
+			_ = s.clientsCache.Add(winner.intent.Label, winner.client, cache.DefaultExpiration)
+		}
+		// Then we mirror the request to the shadows of the ability, if any, unless a hook answered instead of it.
+		if result.called {
+			s.mirror(ctx, winner.client, request, response)
+		}
+		// And we make sure the response contains the last ability used.
+		response.Context.LastAbility = winner.client.Name
+		// The ability may let us answer the next identical requests with the same response. It is cached for the intent
+		// of the first candidate, the one the next requests are looked up with.
+		s.responseCache.add(candidates[0].intent.Label, winner.client.Name, request, response)
+		// The ability may be working on a long-running job, the response being only an acknowledgement.
+		if response.Job != nil {
+			return s.startJob(winner.client, request, response)
 		}
+		// Finally, the ability may have forwarded the turn to another one.
+		return s.followRedirects(ctx, response, winner.client.Name, request)
 	}
 
-	return ability.NewSimpleResponse("I didn't find any ability corresponding to your request.")
+	return errorResponse(err)
+}
+
+// errorResponse chooses the response to the user from the kind of error the ability resolution or call failed with.
+func errorResponse(err error) *ability.Response {
+	var unsupported *unsupportedDeviceError
+	if errors.As(err, &unsupported) {
+		return ability.NewSimpleResponse(fmt.Sprintf(
+			"Your device can't do that, it needs: %s.", strings.Join(unsupported.missing, ", ")))
+	}
+
+	switch ability.KindOf(err) {
+	case ability.KindUnavailable:
+		return ability.NewSimpleResponse("This service is unavailable for now, please try again later.")
+	case ability.KindBusy:
+		return ability.NewSimpleResponse("This service is busy, please try again in a moment.")
+	case ability.KindTimeout:
+		return ability.NewSimpleResponse("This service took too long to answer, please try again later.")
+	case ability.KindTransport:
+		return ability.NewSimpleResponse("I couldn't reach this service, please try again later.")
+	case ability.KindAbility:
+		return ability.NewSimpleResponse("This service failed to handle your request.")
+	case ability.KindPayload:
+		return ability.NewSimpleResponse("This service gave me an answer I couldn't understand.")
+	default:
+		return ability.NewSimpleResponse("I didn't find any ability corresponding to your request.")
+	}
+}
+
+// builtinResponse answers the intents handled by oratio itself, without any ability behind: the stop intent and the
+// built-in intents of the configuration.
+func (s *serviceImpl) builtinResponse(intent string, request ability.Request) (*ability.Response, bool) {
+	if intent == s.stopIntent {
+		return ability.NewSimpleResponse(""), true
+	}
+
+	return s.builtins.respond(intent, request)
+}
+
+// resolveCandidates walks the ranked intents and resolves the abilities to request. The first intent having an
+// ability is always a candidate, the following ones only if they score within the fan-out margin of it.
+// If a cached response or a built-in intent comes before any ability, it is returned instead. If the only abilities
+// found can't serve the device, the response explains what the device lacks.
+func (s *serviceImpl) resolveCandidates(ctx context.Context, intents []cerebro.Intent, request ability.Request) ([]candidate, *ability.Response) {
+	candidates := make([]candidate, 0, 1)
+	requested := make(map[string]bool)
+	var unsupported *unsupportedDeviceError
+	for _, intent := range intents {
+		if len(candidates) > 0 && intent.Score < candidates[0].intent.Score-s.fanOutMargin {
+			break
+		}
+
+		if len(candidates) == 0 {
+			if response := s.cachedResponse(intent.Label, request); response != nil {
+				return nil, response
+			}
+		}
+
+		if response, ok := s.builtinResponse(intent.Label, request); ok {
+			if len(candidates) == 0 {
+				return nil, response
+			}
+			continue
+		}
+
+		client, cacheable, err := s.resolveClient(ctx, intent.Label, request.Device)
+		var unsupportedErr *unsupportedDeviceError
+		if errors.As(err, &unsupportedErr) && unsupported == nil {
+			unsupported = unsupportedErr
+		}
+		if err != nil || requested[client.Name] {
+			// No ability (or an already requested one) for this one, fall back on the next intent.
+			continue
+		}
+		requested[client.Name] = true
+		candidates = append(candidates, candidate{intent: intent, client: client, cacheable: cacheable})
+
+		if s.fanOutMargin <= 0 {
+			break
+		}
+	}
+
+	if len(candidates) == 0 && unsupported != nil {
+		return nil, errorResponse(unsupported)
+	}
+	return candidates, nil
 }
 
 // GetCacheAbilities fetch the abilities from the cache.
@@ -112,36 +428,36 @@ func (s *serviceImpl) GetCacheAbilities() ([]*model.Ability, error) {
 		if !ok {
 			return nil, fmt.Errorf("error casting cache entry into %T", &ability.Client{})
 		}
-		abilities = append(abilities, &model.Ability{
-			Name:    client.Name,
-			Host:    client.Host,
-			Port:    client.Port,
-			Intents: []string{intent},
-		})
+		abilities = append(abilities, s.abilityFromClient(client, intent))
 	}
 	return abilities, nil
 }
 
 // GetDatabaseAbilities fetch the abilities from the database.
 func (s *serviceImpl) GetDatabaseAbilities() ([]*model.Ability, error) {
-	return s.dao.GetAll()
+	abilities, err := s.dao.GetAll()
+	for _, ab := range abilities {
+		ab.Secret = ""
+		ab.BreakerState = s.breakers.state(ab.Key())
+		ab.Health = s.health.state(model.Instance{Host: ab.Host, Port: ab.Port, URL: ab.URL})
+		for i := range ab.Instances {
+			ab.Instances[i].Health = s.health.state(ab.Instances[i])
+		}
+	}
+	return abilities, err
 }
 
 // GetConfigAbilities fetch the abilities from the configuration.
 func (s *serviceImpl) GetConfigAbilities() ([]*model.Ability, error) {
-	abilities := make([]*model.Ability, 0)
-	for intent, client := range s.clientsFromConfig {
-		abilities = append(abilities, &model.Ability{
-			Name:    client.Name,
-			Host:    client.Host,
-			Port:    client.Port,
-			Intents: []string{intent},
-		})
-	}
-	return abilities, nil
+	return s.describe(s.fromConfig), nil
+}
+
+// GetFilesAbilities fetch the abilities from the files of the discovery directory.
+func (s *serviceImpl) GetFilesAbilities() ([]*model.Ability, error) {
+	return s.describe(s.fromFiles.set()), nil
 }
 
-// GetAllAbilities fetch the abilities from the every place (cache, database, config).
+// GetAllAbilities fetch the abilities from the every place (cache, database, config, files, in process).
 func (s *serviceImpl) GetAllAbilities() (*model.Abilities, error) {
 	result := &model.Abilities{}
 	var err error
@@ -160,39 +476,159 @@ func (s *serviceImpl) GetAllAbilities() (*model.Abilities, error) {
 		logrus.WithError(err).Error("An error occurred while fetching Abilities from config")
 		return nil, err
 	}
+	result.Files, err = s.GetFilesAbilities()
+	if err != nil {
+		logrus.WithError(err).Error("An error occurred while fetching Abilities from files")
+		return nil, err
+	}
+	result.InProcess, err = s.GetInProcessAbilities()
+	if err != nil {
+		logrus.WithError(err).Error("An error occurred while fetching in-process Abilities")
+		return nil, err
+	}
 	return result, nil
 }
+
+// CreateOrUpdate registers the ability in the database, with a lease if it has a TTL. An ability registering without
+// intents is filled from its manifest.
 func (s *serviceImpl) CreateOrUpdate(ability *model.Ability) (*model.Ability, error) {
-	return s.dao.CreateOrUpdate(ability)
+	if err := s.validateAbility(ability); err != nil {
+		return nil, err
+	}
+	if err := s.applyManifest(ability); err != nil {
+		return nil, err
+	}
+	s.leases.grant(ability)
+	result, err := s.dao.CreateOrUpdate(ability)
+	if err == nil {
+		s.leases.track(result)
+		// The new shadow is mirrored right away, by this instance of oratio at least.
+		if result.ShadowOf != "" {
+			s.shadowsCache.Delete(result.ShadowOf)
+		}
+	}
+	if result != nil {
+		result.Secret = ""
+	}
+	return result, err
+}
+
+// GetShadowDiffs returns the last differences between the responses of the abilities and of their shadows, filtered by
+// ability and shadow names if not empty.
+func (s *serviceImpl) GetShadowDiffs(abilityName string, shadowName string) []*model.ShadowDiff {
+	return s.shadowDiffs.list(abilityName, shadowName)
 }
 
-func (s *serviceImpl) resolveClient(intentOrAbility string) (*ability.Client, bool) {
-	// Resolve from cache
+// resolveClient finds the client of the ability handling the intent, or the ability name, among the cache, the
+// database, the files, the configuration and the in-process abilities. The abilities seen unhealthy by the health
+// checker and the ones requiring capabilities the device doesn't have are skipped. When several versions of an ability
+// are registered, the device is routed to one of them according to their traffic shares, the client being then never
+// cached by intent. Only the clients of the database are cached: the files and the configuration are kept in memory,
+// a changed or removed file being followed right away, and a new ability of the database taking precedence over them.
+func (s *serviceImpl) resolveClient(ctx context.Context, intentOrAbility string, device ability.Device) (client *ability.Client, cacheable bool, err error) {
+	var unsupported *unsupportedDeviceError
+	accept := func(location string, client *ability.Client) bool {
+		if !client.Available() {
+			logSkippedUnhealthyClient(location, intentOrAbility, client.Name)
+			return false
+		}
+		if missing := client.MissingCapabilities(device); len(missing) > 0 {
+			logSkippedUnsupportedClient(location, intentOrAbility, client.Name, missing)
+			if unsupported == nil {
+				unsupported = &unsupportedDeviceError{ability: client.Name, missing: missing}
+			}
+			return false
+		}
+		logResolvedClientFrom(location, intentOrAbility, client.Name)
+		trace.SpanFromContext(ctx).SetAttributes(
+			attribute.String("ability.name", client.Name),
+			attribute.String("ability.version", client.Version),
+			attribute.String("ability.source", location),
+		)
+		return true
+	}
+
+	// Resolve from cache, forgetting the client whose lease has expired, or whose ability got other versions since
 	if cachedClient, ok := s.clientsCache.Get(intentOrAbility); ok {
-		client := cachedClient.(*ability.Client)
-		logResolvedClientFrom("cache", intentOrAbility, client.Name)
-		return client, true
+		if client := cachedClient.(*ability.Client); s.leases.expired(client) || s.hasSeveralVersions(client.Name) {
+			s.clientsCache.Delete(intentOrAbility)
+		} else if accept("cache", client) {
+			return client, true, nil
+		}
 	}
 
-	// If not found, resolve from database
-	abilities, err := s.dao.GetByIntent(intentOrAbility)
-	if err == nil && len(abilities) > 0 {
-		client := ability.NewClient(abilities[0].Host, abilities[0].Port, abilities[0].Name)
-		logResolvedClientFrom("database", intentOrAbility, client.Name)
-		return client, true
+	// If not found, resolve from database, by intent then by ability name (a redirect or a slot filling)
+	var abilities []*model.Ability
+	abilities, err = s.dao.GetByIntent(intentOrAbility)
+	if err == nil && len(abilities) == 0 {
+		abilities, err = s.dao.GetByName(intentOrAbility)
+	}
+	for _, ab := range splitTraffic(ctx, abilities, device.ID) {
+		s.leases.track(ab)
+		if client := s.newClient(ab); accept("database", client) {
+			return client, countVersions(abilities, ab.Name) == 1, nil
+		}
+	}
+
+	// If not found, resolve from files
+	files := s.fromFiles.set()
+	for _, ab := range splitTraffic(ctx, files.byIntent[intentOrAbility], device.ID) {
+		if client := files.clients[ab.Key()]; accept("files", client) {
+			return client, false, nil
+		}
 	}
 
 	// If not found, resolve from config
-	if client, ok := s.clientsFromConfig[intentOrAbility]; ok {
-		logResolvedClientFrom("configuration", intentOrAbility, client.Name)
-		return client, true
+	for _, ab := range splitTraffic(ctx, s.fromConfig.byIntent[intentOrAbility], device.ID) {
+		if client := s.fromConfig.clients[ab.Key()]; accept("configuration", client) {
+			return client, false, nil
+		}
+	}
+
+	// If not found, resolve from the in-process abilities
+	for _, client := range s.localByIntent[intentOrAbility] {
+		if accept("in-process", client) {
+			return client, false, nil
+		}
+	}
+
+	if unsupported != nil {
+		return nil, false, unsupported
 	}
 
 	logrus.
 		WithError(err).
 		WithField("intentOrAbility", intentOrAbility).
 		Error("Didn't find any ability for this intent or ability name.")
-	return nil, false
+	return nil, false, errNoAbility
+}
+
+// healthTargets lists every instance of the abilities known from the configuration, the database and the cache.
+func (s *serviceImpl) healthTargets() map[string]healthTarget {
+	targets := make(map[string]healthTarget)
+	addClient := func(client *ability.Client) {
+		for _, i := range client.Instances() {
+			targets[instanceKey(i)] = healthTarget{client: client, instance: i}
+		}
+	}
+
+	for _, client := range s.fromConfig.clients {
+		addClient(client)
+	}
+	for _, client := range s.fromFiles.set().clients {
+		addClient(client)
+	}
+	if abilities, err := s.dao.GetAll(); err == nil {
+		for _, ab := range abilities {
+			addClient(s.newClient(ab))
+		}
+	}
+	for _, item := range s.clientsCache.Items() {
+		if client, ok := item.Object.(*ability.Client); ok {
+			addClient(client)
+		}
+	}
+	return targets
 }
 
 func logResolvedClientFrom(location string, intentOrAbility string, client string) {
@@ -201,3 +637,18 @@ func logResolvedClientFrom(location string, intentOrAbility string, client strin
 		WithField("client", client).
 		Debugf("Resolved the client from %s to request ability.", location)
 }
+
+func logSkippedUnhealthyClient(location string, intentOrAbility string, client string) {
+	logrus.
+		WithField("intentOrAbility", intentOrAbility).
+		WithField("client", client).
+		Debugf("Skipped the client from %s, none of its instances is healthy.", location)
+}
+
+func logSkippedUnsupportedClient(location string, intentOrAbility string, client string, missing []string) {
+	logrus.
+		WithField("intentOrAbility", intentOrAbility).
+		WithField("client", client).
+		WithField("missingCapabilities", missing).
+		Debugf("Skipped the client from %s, the device lacks some required capabilities.", location)
+}
@@ -0,0 +1,214 @@
+package ability
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+	"github.com/milobella/oratio/pkg/anima"
+	"github.com/milobella/oratio/pkg/cerebro"
+)
+
+// fakeDAO keeps the abilities in memory, as the database would.
+type fakeDAO struct {
+	abilities []*model.Ability
+}
+
+func (d *fakeDAO) CreateOrUpdate(ab *model.Ability) (*model.Ability, error) {
+	for i, registered := range d.abilities {
+		if registered.Name == ab.Name && registered.Version == ab.Version {
+			d.abilities[i] = ab
+			return ab, nil
+		}
+	}
+	d.abilities = append(d.abilities, ab)
+	return ab, nil
+}
+
+func (d *fakeDAO) GetAll() ([]*model.Ability, error) {
+	return d.abilities, nil
+}
+
+func (d *fakeDAO) GetByIntent(intent string) ([]*model.Ability, error) {
+	var result []*model.Ability
+	for _, ab := range d.abilities {
+		for _, i := range ab.Intents {
+			if i == intent && ab.ShadowOf == "" {
+				result = append(result, ab)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (d *fakeDAO) GetByName(name string) ([]*model.Ability, error) {
+	var result []*model.Ability
+	for _, ab := range d.abilities {
+		if ab.Name == name && ab.ShadowOf == "" {
+			result = append(result, ab)
+		}
+	}
+	return result, nil
+}
+
+func (d *fakeDAO) GetShadows(name string) ([]*model.Ability, error) {
+	var result []*model.Ability
+	for _, ab := range d.abilities {
+		if ab.ShadowOf == name {
+			result = append(result, ab)
+		}
+	}
+	return result, nil
+}
+
+func (d *fakeDAO) Renew(string, string) (*model.Ability, error) {
+	return nil, ErrUnknownLease
+}
+
+func (d *fakeDAO) SetManifest(string, string, *model.Manifest) error {
+	return nil
+}
+
+func newTestService(conf config.Abilities, abilities ...*model.Ability) *serviceImpl {
+	conf.Cache = config.Cache{Expiration: time.Hour, CleanupInterval: time.Hour}
+	return NewService(&fakeDAO{abilities: abilities}, conf).(*serviceImpl)
+}
+
+func TestGetRankedIntentsOrAbility(t *testing.T) {
+	s := &serviceImpl{stopIntent: "STOP", minScore: 0.5}
+	slotFilling := ability.Context{LastAbility: "cinema", SlotFilling: map[string]interface{}{"missing": "date"}}
+
+	tests := []struct {
+		name    string
+		intents []cerebro.Intent
+		context ability.Context
+		want    []string
+	}{
+		{
+			name:    "ranked from the best score",
+			intents: []cerebro.Intent{{Label: "GET_TIME", Score: 0.6}, {Label: "LAST_SHOWTIME", Score: 0.9}, {Label: "WEATHER", Score: 0.7}},
+			want:    []string{"LAST_SHOWTIME", "WEATHER", "GET_TIME"},
+		},
+		{
+			name:    "below the minimum score",
+			intents: []cerebro.Intent{{Label: "GET_TIME", Score: 0.4}, {Label: "LAST_SHOWTIME", Score: 0.5}},
+			want:    []string{"LAST_SHOWTIME"},
+		},
+		{
+			name:    "nothing reaching the minimum score",
+			intents: []cerebro.Intent{{Label: "GET_TIME", Score: 0.1}},
+			want:    []string{},
+		},
+		{
+			name:    "without label",
+			intents: []cerebro.Intent{{Label: "", Score: 0.9}, {Label: "GET_TIME", Score: 0.8}},
+			want:    []string{"GET_TIME"},
+		},
+		{
+			name:    "ties kept in the NLU order",
+			intents: []cerebro.Intent{{Label: "GET_TIME", Score: 0.8}, {Label: "WEATHER", Score: 0.8}},
+			want:    []string{"GET_TIME", "WEATHER"},
+		},
+		{
+			name:    "slot filling forced to the last ability",
+			intents: []cerebro.Intent{{Label: "GET_TIME", Score: 0.9}},
+			context: slotFilling,
+			want:    []string{"cinema"},
+		},
+		{
+			name:    "slot filling without any intent",
+			context: slotFilling,
+			want:    []string{"cinema"},
+		},
+		{
+			name:    "slot filling stopped by the stop intent",
+			intents: []cerebro.Intent{{Label: "STOP", Score: 0.9}, {Label: "GET_TIME", Score: 0.6}},
+			context: slotFilling,
+			want:    []string{"STOP", "GET_TIME"},
+		},
+		{
+			name:    "slot filling kept when the stop intent is not the best one",
+			intents: []cerebro.Intent{{Label: "GET_TIME", Score: 0.9}, {Label: "STOP", Score: 0.6}},
+			context: slotFilling,
+			want:    []string{"cinema"},
+		},
+		{
+			name:    "slot filling kept when the stop intent is below the minimum score",
+			intents: []cerebro.Intent{{Label: "STOP", Score: 0.3}},
+			context: slotFilling,
+			want:    []string{"cinema"},
+		},
+		{
+			name:    "last ability without slot filling",
+			intents: []cerebro.Intent{{Label: "GET_TIME", Score: 0.9}},
+			context: ability.Context{LastAbility: "cinema"},
+			want:    []string{"GET_TIME"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranked := s.getRankedIntentsOrAbility(cerebro.NLU{Intents: tt.intents}, tt.context)
+			got := make([]string, 0, len(ranked))
+			for _, intent := range ranked {
+				got = append(got, intent.Label)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("getRankedIntentsOrAbility() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveCandidatesFallsBackOnTheNextIntent(t *testing.T) {
+	s := newTestService(config.Abilities{
+		StopIntent: "STOP",
+		Builtins:   []config.Builtin{{Intents: []string{"HELLO"}, Nlg: anima.NLG{Sentence: "Hello!"}}},
+		List: []model.Ability{
+			{Name: "clock", Intents: []string{"GET_TIME"}, Host: "clock", Port: 10300},
+			{Name: "cinema", Intents: []string{"LAST_SHOWTIME"}, Host: "cinema", Port: 10300, RequiredCapabilities: []string{"screen"}},
+		},
+	})
+	screen := ability.Device{Capabilities: []string{"screen"}}
+
+	tests := []struct {
+		name         string
+		intents      []string
+		device       ability.Device
+		want         string
+		wantResponse bool
+		wantSentence string
+	}{
+		{"best intent", []string{"GET_TIME", "LAST_SHOWTIME"}, screen, "clock", false, ""},
+		{"best intent without ability", []string{"UNKNOWN", "GET_TIME"}, screen, "clock", false, ""},
+		{"best ability not serving the device", []string{"LAST_SHOWTIME", "GET_TIME"}, ability.Device{}, "clock", false, ""},
+		{"no ability serving the device", []string{"LAST_SHOWTIME", "UNKNOWN"}, ability.Device{}, "", true, "Your device can't do that, it needs: screen."},
+		{"built-in intent before any ability", []string{"UNKNOWN", "HELLO", "GET_TIME"}, screen, "", true, "Hello!"},
+		{"stop intent", []string{"STOP", "GET_TIME"}, screen, "", true, ""},
+		{"no ability at all", []string{"UNKNOWN"}, screen, "", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			intents := make([]cerebro.Intent, 0, len(tt.intents))
+			for i, label := range tt.intents {
+				intents = append(intents, cerebro.Intent{Label: label, Score: 0.9 - float32(i)/10})
+			}
+			candidates, response := s.resolveCandidates(context.Background(), intents, ability.Request{Device: tt.device})
+			if tt.want != "" {
+				if response != nil || len(candidates) != 1 || candidates[0].client.Name != tt.want {
+					t.Fatalf("resolveCandidates() = %v, %v, want %s", candidates, response, tt.want)
+				}
+				return
+			}
+			if len(candidates) != 0 {
+				t.Fatalf("resolveCandidates() = %v, want no candidate", candidates)
+			}
+			if (response != nil) != tt.wantResponse || (response != nil && response.Nlg.Sentence != tt.wantSentence) {
+				t.Errorf("resolveCandidates() answered %v, want a response: %v, saying %q", response, tt.wantResponse, tt.wantSentence)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package ability
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+	"github.com/patrickmn/go-cache"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const defaultMaxShadowDiffs = 1000
+
+// shadowDiffs keeps the last differences between the responses of the abilities and of their shadows.
+type shadowDiffs struct {
+	mutex sync.RWMutex
+	diffs []*model.ShadowDiff
+	// next is the index the next diff will be written at, the diffs being a ring buffer once full.
+	next int
+}
+
+func newShadowDiffs(capacity int) *shadowDiffs {
+	if capacity <= 0 {
+		capacity = defaultMaxShadowDiffs
+	}
+	return &shadowDiffs{diffs: make([]*model.ShadowDiff, 0, capacity)}
+}
+
+func (d *shadowDiffs) add(diff *model.ShadowDiff) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if len(d.diffs) < cap(d.diffs) {
+		d.diffs = append(d.diffs, diff)
+	} else {
+		d.diffs[d.next] = diff
+	}
+	d.next = (d.next + 1) % cap(d.diffs)
+}
+
+// list returns the diffs from the oldest to the newest, filtered by ability and shadow names if not empty.
+func (d *shadowDiffs) list(abilityName string, shadowName string) []*model.ShadowDiff {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	result := make([]*model.ShadowDiff, 0, len(d.diffs))
+	for i := range d.diffs {
+		diff := d.diffs[(d.next+i)%len(d.diffs)]
+		if (abilityName == "" || diff.Ability == abilityName) && (shadowName == "" || diff.Shadow == shadowName) {
+			result = append(result, diff)
+		}
+	}
+	return result
+}
+
+// mirror sends, in background, a copy of the request the live ability succeeded to answer to its shadows, according
+// to their traffic shares. The responses of the shadows are compared with the live one and never given to the user.
+func (s *serviceImpl) mirror(ctx context.Context, live *ability.Client, request ability.Request, response *ability.Response) {
+	// The live response is snapshot right away, it could be modified once given back.
+	liveFields := comparedFields(response)
+	// The shadows are still requested once the user has been answered, the trace of the request being kept only.
+	ctx = trace.ContextWithSpan(context.Background(), trace.SpanFromContext(ctx))
+	go func() {
+		for _, shadow := range s.shadowsOf(live.Name) {
+			if rand.Intn(100) >= shadow.traffic {
+				continue
+			}
+			diff := &model.ShadowDiff{Ability: live.Name, Shadow: shadow.client.Name, Time: time.Now(), Text: request.Nlu.Text}
+			shadowResponse, called, err := s.callAbility(ctx, shadow.client, request)
+			if !called {
+				// A hook answered instead of the shadow, there is nothing to compare.
+				continue
+			}
+			if err != nil {
+				diff.Error = err.Error()
+			} else if diff.Fields = diffFields(liveFields, comparedFields(shadowResponse)); len(diff.Fields) == 0 {
+				continue
+			}
+			logrus.
+				WithField("ability", diff.Ability).
+				WithField("shadow", diff.Shadow).
+				WithField("fields", len(diff.Fields)).
+				WithField("error", diff.Error).
+				Info("The shadow's response differs from the ability's one.")
+			s.shadowDiffs.add(diff)
+		}
+	}()
+}
+
+type shadowClient struct {
+	client  *ability.Client
+	traffic int
+}
+
+// shadowsOf lists the shadows of the ability, from the database, the files and the configuration. The shadows from
+// the database are cached by ability, even when there are none, not to query it after every request.
+func (s *serviceImpl) shadowsOf(name string) []shadowClient {
+	var shadows []*model.Ability
+	if cached, ok := s.shadowsCache.Get(name); ok {
+		shadows = cached.([]*model.Ability)
+	} else if fetched, err := s.dao.GetShadows(name); err != nil {
+		logrus.WithError(err).WithField("ability", name).Error("Error fetching the shadows from the database.")
+	} else {
+		shadows = fetched
+		s.shadowsCache.Set(name, shadows, cache.DefaultExpiration)
+	}
+
+	result := make([]shadowClient, 0, len(shadows))
+	for _, shadow := range shadows {
+		// The cached shadows may have been registered with a lease which has expired since.
+		if shadow.ExpiresAt != nil && time.Now().After(*shadow.ExpiresAt) {
+			continue
+		}
+		result = append(result, shadowClient{client: s.newClient(shadow), traffic: shadow.Traffic})
+	}
+	for _, set := range []*abilitySet{s.fromFiles.set(), s.fromConfig} {
+		for _, shadow := range set.shadows[name] {
+			result = append(result, shadowClient{client: set.clients[shadow.Key()], traffic: shadow.Traffic})
+		}
+	}
+	return result
+}
+
+// comparedFieldNames are the fields of the responses compared between the abilities and their shadows.
+var comparedFieldNames = []string{"nlg.sentence", "nlg.params", "actions", "context"}
+
+// comparedFields extracts the compared fields of a response, in their JSON form so that they can be compared
+// whatever their Go types.
+func comparedFields(response *ability.Response) map[string]interface{} {
+	context := response.Context
+	// The last ability is set by oratio, not by the ability.
+	context.LastAbility = ""
+	raw := map[string]interface{}{
+		"nlg.sentence": response.Nlg.Sentence,
+		"nlg.params":   response.Nlg.Params,
+		"actions":      response.Actions,
+		"context":      context,
+	}
+	fields := make(map[string]interface{}, len(raw))
+	for name, value := range raw {
+		var field interface{}
+		if bytes, err := json.Marshal(value); err == nil && json.Unmarshal(bytes, &field) == nil {
+			fields[name] = field
+		}
+	}
+	return fields
+}
+
+func diffFields(live map[string]interface{}, shadow map[string]interface{}) []model.FieldDiff {
+	var diffs []model.FieldDiff
+	for _, name := range comparedFieldNames {
+		if !reflect.DeepEqual(live[name], shadow[name]) {
+			diffs = append(diffs, model.FieldDiff{Field: name, Live: live[name], Shadow: shadow[name]})
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,62 @@
+package ability
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+	"github.com/milobella/oratio/pkg/cerebro"
+)
+
+// blockingHook answers instead of the ability for the blocked device, as a policy would.
+type blockingHook struct {
+	ability string
+	device  string
+}
+
+func (h blockingHook) BeforeCall(client string, request *ability.Request) *ability.Response {
+	if client == h.ability && request.Device.ID == h.device {
+		return ability.NewSimpleResponse("You are not allowed to do that.")
+	}
+	return nil
+}
+
+func (h blockingHook) AfterCall(_ string, _ ability.Request, response *ability.Response) *ability.Response {
+	return response
+}
+
+func TestMirrorOnlyTheResponsesOfTheAbility(t *testing.T) {
+	server := newAbilitiesServer(t, map[string]*ability.Response{
+		"clock":      ability.NewSimpleResponse("It is noon."),
+		"clock-next": ability.NewSimpleResponse("It is 12:00."),
+	})
+	s := newTestService(config.Abilities{},
+		&model.Ability{Name: "clock", Intents: []string{"GET_TIME"}, URL: server.URL + "/clock"},
+		&model.Ability{Name: "clock-next", ShadowOf: "clock", Traffic: 100, Intents: []string{"GET_TIME"}, URL: server.URL + "/clock-next"},
+	)
+	s.hooks = []Hook{blockingHook{ability: "clock", device: "blocked"}}
+	nlu := cerebro.NLU{Intents: []cerebro.Intent{{Label: "GET_TIME", Score: 1}}}
+
+	// The hook answering instead of the ability, there is nothing to compare the shadow with.
+	blocked := s.RequestAbility(context.Background(), nlu, ability.Context{}, ability.Device{ID: "blocked"})
+	if blocked.Nlg.Sentence != "You are not allowed to do that." {
+		t.Fatalf("RequestAbility() = %q for the blocked device", blocked.Nlg.Sentence)
+	}
+	s.RequestAbility(context.Background(), nlu, ability.Context{}, ability.Device{ID: "allowed"})
+
+	// The mirroring being in background, the diff of the allowed request is waited for.
+	var diffs []*model.ShadowDiff
+	for deadline := time.Now().Add(2 * time.Second); len(diffs) == 0 && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		diffs = s.GetShadowDiffs("clock", "")
+	}
+	if len(diffs) != 1 {
+		t.Fatalf("GetShadowDiffs() = %d diffs, want the one of the allowed request only", len(diffs))
+	}
+	if diffs[0].Shadow != "clock-next" || len(diffs[0].Fields) == 0 {
+		t.Errorf("GetShadowDiffs() = %+v, want the sentence of clock-next to differ", diffs[0])
+	}
+}
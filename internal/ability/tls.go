@@ -0,0 +1,41 @@
+package ability
+
+import (
+	"crypto/tls"
+	"sync"
+
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/ability"
+	"github.com/sirupsen/logrus"
+)
+
+// tlsConfigs keeps the TLS configurations loaded from the files of the abilities, so that the files are read once and
+// the connections are reused by the clients rebuilt from the database.
+type tlsConfigs struct {
+	mutex  sync.Mutex
+	byConf map[model.TLS]*tls.Config
+}
+
+func newTLSConfigs() *tlsConfigs {
+	return &tlsConfigs{byConf: make(map[model.TLS]*tls.Config)}
+}
+
+// get returns the TLS configuration of the ability, nil if it has none, or an error if it can't be loaded.
+func (t *tlsConfigs) get(ab *model.Ability) (*tls.Config, error) {
+	if ab.TLS == nil {
+		return nil, nil
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if config, ok := t.byConf[*ab.TLS]; ok {
+		return config, nil
+	}
+	config, err := ability.LoadTLSConfig(ab.TLS.CAFile, ab.TLS.CertFile, ab.TLS.KeyFile, ab.TLS.ServerName)
+	if err != nil {
+		logrus.WithError(err).WithField("ability", ab.Key()).Error("Error loading the TLS configuration of the ability.")
+		return nil, err
+	}
+	t.byConf[*ab.TLS] = config
+	return config, nil
+}
@@ -0,0 +1,57 @@
+package ability
+
+import (
+	"errors"
+
+	"github.com/milobella/oratio/pkg/ability"
+	"github.com/sirupsen/logrus"
+)
+
+// validation is the policy applied to the responses whose visu or actions don't match the schema of pkg/ability.
+type validation string
+
+const (
+	// validationOff passes the responses as they are.
+	validationOff validation = "off"
+	// validationLog logs the malformed responses, which are given to the device anyway.
+	validationLog validation = "log"
+	// validationReject fails the calls answering malformed responses, as if the ability answered an invalid payload.
+	validationReject validation = "reject"
+)
+
+func newValidation(policy string) validation {
+	switch validation(policy) {
+	case validationOff, validationReject:
+		return validation(policy)
+	case validationLog, "":
+		return validationLog
+	default:
+		logrus.WithField("validation", policy).Warn("Unknown validation policy, falling back to log.")
+		return validationLog
+	}
+}
+
+// IsMalformedResponse tells whether the error comes from a response rejected by the validation.
+func IsMalformedResponse(err error) bool {
+	var schemaErr *ability.SchemaError
+	return errors.As(err, &schemaErr)
+}
+
+// check validates the response of the ability named client according to the policy. An error is returned only when
+// the response is rejected.
+func (v validation) check(client string, response *ability.Response) error {
+	if v == validationOff {
+		return nil
+	}
+	err := ability.ValidateResponse(response)
+	if err == nil {
+		return nil
+	}
+	logger := logrus.WithError(err).WithField("client", client)
+	if v == validationReject {
+		logger.Error("The ability answered a malformed response, rejecting it.")
+		return &ability.Error{Kind: ability.KindPayload, Err: err}
+	}
+	logger.Warn("The ability answered a malformed response.")
+	return nil
+}
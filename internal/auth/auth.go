@@ -1,15 +1,46 @@
 package auth
 
 import (
+	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/milobella/oratio/internal/config"
 )
 
-func ApplyMiddleware(server *echo.Echo, configuration config.Auth) {
+// deviceClaim is the claim of the token identifying the device, the subject identifying it if omitted.
+const deviceClaim = "device"
+
+// ApplyMiddleware requires the token of the app on every route, except the given ones (routes as registered, with
+// their params), which authenticate their requests by themselves.
+func ApplyMiddleware(server *echo.Echo, configuration config.Auth, skippedRoutes ...string) {
 	if len(configuration.AppSecret) > 0 {
+		skipped := make(map[string]bool, len(skippedRoutes))
+		for _, route := range skippedRoutes {
+			skipped[route] = true
+		}
 		// TODO: use custom claim to retrieve scopes and other user info (https://echo.labstack.com/cookbook/jwt)
 		//  https://github.com/milobella/oratio/issues/12
-		server.Use(middleware.JWT([]byte(configuration.AppSecret)))
+		server.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+			SigningKey: []byte(configuration.AppSecret),
+			Skipper:    func(c echo.Context) bool { return skipped[c.Path()] },
+		}))
+	}
+}
+
+// DeviceID returns the ID of the device identified by the token of the request, and whether the request is
+// authenticated at all. The ID is empty if the token of an authenticated request identifies no device.
+func DeviceID(c echo.Context) (string, bool) {
+	token, ok := c.Get(middleware.DefaultJWTConfig.ContextKey).(*jwt.Token)
+	if !ok {
+		return "", false
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", true
+	}
+	if device, ok := claims[deviceClaim].(string); ok && device != "" {
+		return device, true
 	}
+	subject, _ := claims["sub"].(string)
+	return subject, true
 }
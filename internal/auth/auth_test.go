@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/milobella/oratio/internal/config"
+)
+
+func TestApplyMiddlewareSkipsTheGivenRoutes(t *testing.T) {
+	server := echo.New()
+	ApplyMiddleware(server, config.Auth{AppSecret: "secret"}, "/api/v1/abilities/:name/jobs/:id")
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }
+	server.POST("/api/v1/talk/text", ok)
+	server.POST("/api/v1/abilities/:name/jobs/:id", ok)
+
+	tests := []struct {
+		method string
+		target string
+		want   int
+	}{
+		{http.MethodPost, "/api/v1/talk/text", http.StatusBadRequest},
+		{http.MethodPost, "/api/v1/abilities/cinema/jobs/b5e2c1", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.target, nil))
+			if recorder.Code != tt.want {
+				t.Errorf("status = %d without token, want %d", recorder.Code, tt.want)
+			}
+		})
+	}
+}
@@ -5,16 +5,18 @@ import (
 	"time"
 
 	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/pkg/anima"
 	"github.com/sirupsen/logrus"
 )
 
 type Config struct {
-	Server    Server
-	Tracing   Tracing
-	Auth      Auth
-	Cerebro   Cerebro
-	Anima     Anima
-	Abilities Abilities
+	Server        Server
+	Tracing       Tracing
+	Auth          Auth
+	Cerebro       Cerebro
+	Anima         Anima
+	Abilities     Abilities
+	Notifications Notifications
 }
 
 // fun String() : Serialization function of Config (for logging)
@@ -59,6 +61,127 @@ type Abilities struct {
 	Cache      Cache
 	Database   Database
 	StopIntent string `mapstructure:"stop_intent"`
+	// MinScore is the minimum NLU score an intent must reach to be routed to an ability.
+	MinScore float32 `mapstructure:"min_score"`
+	FanOut   FanOut  `mapstructure:"fan_out"`
+	Health   Health
+	// Timeout of every attempt to request the abilities which don't define their own.
+	Timeout  time.Duration
+	Builtins []Builtin
+	// MaxRedirects is the number of times the abilities can forward a turn to each other (3 if omitted).
+	MaxRedirects int `mapstructure:"max_redirects"`
+	Shadow       Shadow
+	Jobs         Jobs
+	// Validation of the visu and actions of the responses against their schema: "off", "log" (default) or "reject".
+	Validation string
+	// Manifests configures the fetching of the manifests of the abilities registering without intents.
+	Manifests Manifests
+	// Secrets the abilities authenticate oratio with, by name. The registrations only reference them, so that they
+	// can't make oratio send any environment variable or file.
+	Secrets map[string]Secret
+	// Leases configures the TTL of the abilities registering themselves, which renew it by heartbeats.
+	Leases Leases
+	// Files configures the discovery of the abilities from a directory of files.
+	Files Files
+	// ResponseCache keeps the responses the abilities marked as cacheable.
+	ResponseCache ResponseCache `mapstructure:"response_cache"`
+}
+
+// Manifests configures the fetching of the manifests the abilities describe themselves with, at their registration and
+// periodically to flag the drifts.
+type Manifests struct {
+	// Endpoint of the abilities serving their manifest, "manifest" if omitted.
+	Endpoint string
+	// Timeout of a single fetch, the default timeout of the abilities if omitted.
+	Timeout time.Duration
+	// RefreshInterval between two fetches of the manifests. Zero disables the refresh.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+// Secret held by an environment variable or a file, which can only be sent to the abilities served by some hosts.
+type Secret struct {
+	// Env is the environment variable holding the secret.
+	Env string
+	// File holding the secret, read if there is no environment variable.
+	File string
+	// Hosts the secret can be sent to. A secret without hosts is never sent.
+	Hosts []string
+}
+
+// Leases configures the leases of the abilities registered in the database.
+type Leases struct {
+	// DefaultTTL of the abilities registering without TTL, which are registered for good if omitted.
+	DefaultTTL time.Duration `mapstructure:"default_ttl"`
+	// MaxTTL caps the TTL the abilities register with (no cap if omitted).
+	MaxTTL time.Duration `mapstructure:"max_ttl"`
+}
+
+// Files configures the discovery of the abilities from the JSON and TOML files of a directory, which is watched to keep
+// the abilities in sync with the files.
+type Files struct {
+	// Directory of the files, the discovery being disabled if omitted.
+	Directory string
+}
+
+// ResponseCache configures the cache of the responses of the abilities.
+type ResponseCache struct {
+	// MaxTTL caps the TTL the abilities give to their responses (no cap if omitted).
+	MaxTTL time.Duration `mapstructure:"max_ttl"`
+	// CleanupInterval between two purges of the expired responses (1 minute if omitted).
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+}
+
+// Jobs configures the long-running jobs of the abilities, whose final responses are delivered later to the devices.
+type Jobs struct {
+	// Timeout after which a job which has not been completed is forgotten (5 minutes if omitted).
+	Timeout time.Duration
+	// Acknowledgement is the sentence given when the ability started a job without giving one.
+	Acknowledgement string
+}
+
+// Notifications configures the delivery of the responses to the devices, outside of their requests.
+type Notifications struct {
+	// MaxPending is the number of notifications kept for a device while it is not listening (100 if omitted).
+	MaxPending int `mapstructure:"max_pending"`
+}
+
+// Shadow configures the comparison of the abilities with their shadows.
+type Shadow struct {
+	// MaxDiffs is the number of differences kept in memory (1000 if omitted).
+	MaxDiffs int `mapstructure:"max_diffs"`
+}
+
+// Builtin is a response given by oratio itself to some intents, without any ability behind.
+type Builtin struct {
+	Intents []string
+	Nlg     anima.NLG
+	// Template tells whether the NLG sentence is a text/template, rendered with the NLU text, entities and the device
+	// state.
+	Template     bool
+	Visu         interface{}
+	Actions      interface{}
+	AutoReprompt bool `mapstructure:"auto_reprompt"`
+}
+
+// FanOut configures the concurrent requesting of abilities when several intents score close together.
+type FanOut struct {
+	// Margin under the best score within which the intents are competing. Zero disables the fan-out, which is the
+	// default: every competing ability is really called, its side effects happening even when its response loses.
+	Margin float32
+	// Arbitration is the policy picking the winning response: "score", "confidence" or "first".
+	Arbitration string
+}
+
+// Health configures the active health checking of the abilities.
+type Health struct {
+	// Interval between two probes of every ability. Zero disables the health checking.
+	Interval time.Duration
+	// Timeout of a single probe, the interval if omitted.
+	Timeout time.Duration
+	// Endpoint of the abilities to probe, "health" if omitted.
+	Endpoint string
+	// FailureThreshold is the number of consecutive failed probes after which an ability is unhealthy.
+	FailureThreshold int `mapstructure:"failure_threshold"`
 }
 
 type Database struct {
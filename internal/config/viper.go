@@ -2,6 +2,7 @@ package config
 
 import (
 	"github.com/iamolegga/enviper"
+	"github.com/mitchellh/mapstructure"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
@@ -21,7 +22,7 @@ func Read() *Config {
 	}
 
 	var config Config
-	if err = e.Unmarshal(&config); err != nil {
+	if err = e.Unmarshal(&config, DecodeHook()); err != nil {
 		fatal(err)
 	} else {
 		logrus.Info("Successfully red configuration !")
@@ -40,6 +41,16 @@ func Read() *Config {
 	return &config
 }
 
+// DecodeHook : On top of the viper's default hooks, we decode the types knowing how to unmarshal text
+// (model.Duration, ...).
+func DecodeHook() viper.DecoderConfigOption {
+	return viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		mapstructure.TextUnmarshallerHookFunc(),
+	))
+}
+
 func fatal(err error) {
 	logrus.WithError(err).Fatal("Error reading config.")
 }
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -16,6 +17,7 @@ func NewAbility(service ability.Service) Ability {
 type Ability interface {
 	Get(c echo.Context) (err error)
 	Create(c echo.Context) (err error)
+	Heartbeat(c echo.Context) (err error)
 }
 
 type abilityImpl struct {
@@ -48,6 +50,18 @@ func (a *abilityImpl) Get(c echo.Context) (err error) {
 		} else {
 			return c.JSON(http.StatusOK, result)
 		}
+	case "files":
+		if result, err := a.service.GetFilesAbilities(); err != nil {
+			return echo.NewHTTPError(500, err.Error())
+		} else {
+			return c.JSON(http.StatusOK, result)
+		}
+	case "in_process":
+		if result, err := a.service.GetInProcessAbilities(); err != nil {
+			return echo.NewHTTPError(500, err.Error())
+		} else {
+			return c.JSON(http.StatusOK, result)
+		}
 	default:
 		if result, err := a.service.GetAllAbilities(); err != nil {
 			return echo.NewHTTPError(500, err.Error())
@@ -63,9 +77,24 @@ func (a *abilityImpl) Create(c echo.Context) error {
 		return err
 	}
 
-	if result, err := a.service.CreateOrUpdate(futureAbility); err != nil {
+	if result, err := a.service.CreateOrUpdate(futureAbility); ability.IsInvalidAbility(err) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	} else if ability.IsManifestError(err) {
+		return echo.NewHTTPError(http.StatusBadGateway, err.Error())
+	} else if err != nil {
 		return echo.NewHTTPError(500, err.Error())
 	} else {
 		return c.JSON(http.StatusOK, result)
 	}
 }
+
+// Heartbeat renews the lease of the ability, the version being given in the query if the ability is versioned.
+func (a *abilityImpl) Heartbeat(c echo.Context) error {
+	result, err := a.service.Renew(c.Param("name"), c.QueryParam("version"))
+	if errors.Is(err, ability.ErrUnknownLease) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	} else if err != nil {
+		return echo.NewHTTPError(500, err.Error())
+	}
+	return c.JSON(http.StatusOK, result)
+}
@@ -6,14 +6,16 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/milobella/oratio/internal/ability"
 	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/internal/notification"
 	"github.com/milobella/oratio/pkg/anima"
 	"github.com/milobella/oratio/pkg/cerebro"
 	"github.com/sirupsen/logrus"
 )
 
 // New initiates all the handlers and their dependencies from the config.
+// The hooks are registered in the ability service, to be run around every call to an ability.
 // It will return an object containing directly the HandlerFunc to register to the server.
-func New(conf *config.Config) *Handler {
+func New(conf *config.Config, hooks ...ability.Hook) *Handler {
 	// Initialize clients
 	cerebroClient := cerebro.NewClient(conf.Cerebro.Host, conf.Cerebro.Port, conf.Cerebro.UnderstandEndpoint)
 	animaClient := anima.NewClient(conf.Anima.Host, conf.Anima.Port, conf.Anima.RestituteEndpoint)
@@ -24,21 +26,35 @@ func New(conf *config.Config) *Handler {
 	if err != nil {
 		logrus.WithError(err).Fatalf("Error initializing the Ability DAO.")
 	}
-	abilityService := ability.NewService(abilityDAO, conf.Abilities)
+	abilityService := ability.NewService(abilityDAO, conf.Abilities, hooks...)
+
+	// Build the hub delivering the final responses of the jobs to the devices.
+	hub := notification.NewHub(conf.Notifications)
 
 	// Build the handlers
 	abilityHandler := NewAbility(abilityService)
 	textHandler := NewText(cerebroClient, animaClient, abilityService)
+	shadowHandler := NewShadow(abilityService)
+	jobHandler := NewJob(animaClient, abilityService, hub)
+	notificationHandler := NewNotification(hub)
 
 	return &Handler{
-		Text:          textHandler.Send,
-		GetAbilities:  abilityHandler.Get,
-		CreateAbility: abilityHandler.Create,
+		Text:           textHandler.Send,
+		GetAbilities:   abilityHandler.Get,
+		CreateAbility:  abilityHandler.Create,
+		Heartbeat:      abilityHandler.Heartbeat,
+		GetShadowDiffs: shadowHandler.GetDiffs,
+		CompleteJob:    jobHandler.Complete,
+		Notifications:  notificationHandler.Listen,
 	}
 }
 
 type Handler struct {
-	Text          echo.HandlerFunc
-	GetAbilities  echo.HandlerFunc
-	CreateAbility echo.HandlerFunc
+	Text           echo.HandlerFunc
+	GetAbilities   echo.HandlerFunc
+	CreateAbility  echo.HandlerFunc
+	Heartbeat      echo.HandlerFunc
+	GetShadowDiffs echo.HandlerFunc
+	CompleteJob    echo.HandlerFunc
+	Notifications  echo.HandlerFunc
 }
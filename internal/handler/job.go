@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/milobella/oratio/internal/ability"
+	"github.com/milobella/oratio/internal/model"
+	"github.com/milobella/oratio/internal/notification"
+	"github.com/milobella/oratio/pkg/anima"
+)
+
+func NewJob(animaClient *anima.Client, abilityService ability.Service, hub *notification.Hub) Job {
+	return &jobImpl{
+		AnimaClient:    animaClient,
+		AbilityService: abilityService,
+		Hub:            hub,
+	}
+}
+
+type Job interface {
+	Complete(c echo.Context) (err error)
+}
+
+type jobImpl struct {
+	AnimaClient    *anima.Client
+	AbilityService ability.Service
+	Hub            *notification.Hub
+}
+
+// Complete takes the final response of a job from the ability and delivers it to the device which requested it. The
+// request must be signed with the secret shared with the ability.
+func (j *jobImpl) Complete(c echo.Context) (err error) {
+	abilityName, jobID := c.Param("name"), c.Param("id")
+	body, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return
+	}
+	c.Request().Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err = j.AbilityService.VerifyJob(abilityName, jobID, c.Request().Header, body); errors.Is(err, ability.ErrUnknownJob) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	} else if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	response := new(model.JobResult)
+	if err = c.Bind(response); err != nil {
+		return
+	}
+
+	deviceID, response, err := j.AbilityService.CompleteJob(c.Request().Context(), abilityName, jobID, response)
+	if errors.Is(err, ability.ErrUnknownJob) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	} else if ability.IsMalformedResponse(err) {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	} else if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	vocal := j.AnimaClient.GenerateSentence(response.Nlg)
+	j.Hub.Publish(deviceID, &model.Notification{
+		Ability:  abilityName,
+		Job:      jobID,
+		Response: model.NewTextResponse(vocal, response),
+	})
+	return c.NoContent(http.StatusAccepted)
+}
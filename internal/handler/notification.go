@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/milobella/oratio/internal/auth"
+	"github.com/milobella/oratio/internal/notification"
+)
+
+func NewNotification(hub *notification.Hub) Notification {
+	return &notificationImpl{hub: hub}
+}
+
+type Notification interface {
+	Listen(c echo.Context) (err error)
+}
+
+type notificationImpl struct {
+	hub *notification.Hub
+}
+
+// Listen streams the notifications of the device as server-sent events, until the device disconnects. When the
+// requests are authenticated, the device can only listen to its own notifications, identified by its token.
+func (n *notificationImpl) Listen(c echo.Context) (err error) {
+	deviceID := c.QueryParam("device")
+	if identity, authenticated := auth.DeviceID(c); authenticated {
+		if identity == "" || (deviceID != "" && deviceID != identity) {
+			return echo.NewHTTPError(http.StatusForbidden, "the token doesn't identify this device")
+		}
+		deviceID = identity
+	}
+	if deviceID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "the device query param is mandatory")
+	}
+
+	notifications, unsubscribe := n.hub.Subscribe(deviceID)
+	defer unsubscribe()
+
+	c.Response().Header().Set(echo.HeaderContentType, "text/event-stream")
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-cache")
+	c.Response().WriteHeader(http.StatusOK)
+	c.Response().Flush()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case next := <-notifications:
+			data, err := json.Marshal(next)
+			if err != nil {
+				return err
+			}
+			if _, err = fmt.Fprintf(c.Response(), "event: response\ndata: %s\n\n", data); err != nil {
+				return err
+			}
+			c.Response().Flush()
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/milobella/oratio/internal/ability"
+)
+
+func NewShadow(service ability.Service) Shadow {
+	return &shadowImpl{service: service}
+}
+
+type Shadow interface {
+	GetDiffs(c echo.Context) (err error)
+}
+
+type shadowImpl struct {
+	service ability.Service
+}
+
+func (s *shadowImpl) GetDiffs(c echo.Context) (err error) {
+	return c.JSON(http.StatusOK, s.service.GetShadowDiffs(c.QueryParam("ability"), c.QueryParam("shadow")))
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/milobella/oratio/internal/ability"
+	"github.com/milobella/oratio/internal/auth"
 	"github.com/milobella/oratio/internal/model"
 	"github.com/milobella/oratio/pkg/anima"
 	"github.com/milobella/oratio/pkg/cerebro"
@@ -34,21 +35,16 @@ func (rh *textImpl) Send(c echo.Context) (err error) {
 	if err = c.Bind(requestBody); err != nil {
 		return
 	}
+	// The device is the one identified by the token, so that its notifications are delivered to it only.
+	if identity, authenticated := auth.DeviceID(c); authenticated {
+		requestBody.Device.ID = identity
+	}
 
 	// Execute the processing flow
 	nlu := rh.CerebroClient.UnderstandText(requestBody.Text)
-	response := rh.AbilityService.RequestAbility(nlu, requestBody.Context, requestBody.Device)
+	response := rh.AbilityService.RequestAbility(c.Request().Context(), nlu, requestBody.Context, requestBody.Device)
 	vocal := rh.AnimaClient.GenerateSentence(response.Nlg)
 
-	// Build the response's body
-	responseBody := &model.TextResponse{
-		Vocal:        vocal,
-		Visu:         response.Visu,
-		AutoReprompt: response.AutoReprompt,
-		Context:      response.Context,
-		Actions:      response.Actions,
-	}
-
-	// Write it on the http response
-	return c.JSON(http.StatusOK, responseBody)
+	// Write the response's body on the http response
+	return c.JSON(http.StatusOK, model.NewTextResponse(vocal, response))
 }
@@ -1,16 +1,180 @@
 package model
 
+import "time"
+
 // Ability is used in request/response body of the /api/v1/abilities endpoint
 type Ability struct {
-	Name    string   `json:"name"`
-	Host    string   `json:"host"`
-	Port    int      `json:"port"`
+	Name string `json:"name"`
+	// Version of the ability. Several versions of an ability can be registered at the same time to split its traffic.
+	Version string `json:"version,omitempty" bson:"version,omitempty"`
+	// Traffic is the share of the ability's traffic going to this version (or mirrored to this shadow), as a percentage.
+	// The versions without traffic split what the other versions leave of 100.
+	Traffic int    `json:"traffic,omitempty" bson:"traffic,omitempty"`
+	Host    string `json:"host"`
+	Port    int    `json:"port"`
+	// URL is the base URL of the ability, with its scheme and path, taking precedence over the host and port.
+	URL string `json:"url,omitempty" bson:"url,omitempty"`
+	// TLS configures the calls to the ability over https, or over gRPC with TLS.
+	TLS     *TLS     `json:"tls,omitempty" bson:"tls,omitempty"`
 	Intents []string `json:"intents"`
+	// Secret shared with the ability to sign the requests, so that it can authenticate oratio. It is never listed.
+	Secret string `json:"secret,omitempty" bson:"secret,omitempty"`
+	// Headers sent with every request to the ability.
+	Headers map[string]string `json:"headers,omitempty" bson:"headers,omitempty"`
+	// Credentials sent with every request to the ability, referencing by name a secret of the configuration.
+	Credentials *Credentials `json:"credentials,omitempty" bson:"credentials,omitempty"`
+	// Protocol the ability is requested with: "http" (default) or "grpc".
+	Protocol string `json:"protocol,omitempty" bson:"protocol,omitempty"`
+	// RequiredCapabilities are the capabilities a device must have to be served by the ability (screen, speaker, ...).
+	RequiredCapabilities []string `json:"required_capabilities,omitempty" bson:"required_capabilities,omitempty" mapstructure:"required_capabilities"`
+	// ShadowOf is the name of the ability this one is a shadow of. A shadow is never requested for the user: it
+	// receives a copy of the ability's requests, its responses being compared with the ability's ones.
+	ShadowOf string `json:"shadow_of,omitempty" bson:"shadow_of,omitempty" mapstructure:"shadow_of"`
+	// Instances serving the ability in addition to the one given by the host and port.
+	Instances []Instance `json:"instances,omitempty"`
+	// LoadBalancing between the instances: "round_robin" (default), "least_in_flight" or "weighted".
+	LoadBalancing string `json:"load_balancing,omitempty" bson:"load_balancing,omitempty" mapstructure:"load_balancing"`
+	// Timeout of every attempt to request the ability, the default one of the configuration if omitted.
+	Timeout Duration `json:"timeout,omitempty" bson:"timeout,omitempty"`
+	// Retry of the calls failing on the transport or with a 5xx status, no retry if omitted. Only the idempotent abilities
+	// should be retried, as a call timing out may have been handled all the same.
+	Retry *Retry `json:"retry,omitempty" bson:"retry,omitempty"`
+	// CircuitBreaker failing the calls fast while the ability keeps failing, disabled if omitted.
+	CircuitBreaker *CircuitBreaker `json:"circuit_breaker,omitempty" bson:"circuit_breaker,omitempty" mapstructure:"circuit_breaker"`
+	// Limits of the calls to the ability, no limit if omitted.
+	Limits *Limits `json:"limits,omitempty" bson:"limits,omitempty"`
+	// Manifest of the ability, if it was registered from the manifest it serves instead of listing its intents.
+	Manifest *Manifest `json:"manifest,omitempty" bson:"manifest,omitempty"`
+	// TTL of the lease of the ability, which must renew it by heartbeats to stay registered. The ability is registered
+	// for good if omitted, unless a default TTL is configured.
+	TTL Duration `json:"ttl,omitempty" bson:"ttl,omitempty"`
+	// ExpiresAt is the time the lease of the ability expires at, the registration being removed then. It is computed
+	// from the TTL at the registration and at every heartbeat.
+	ExpiresAt *time.Time `json:"expires_at,omitempty" bson:"expires_at,omitempty" mapstructure:"-"`
+	// Health of the instance given by the URL or the host and port. It is computed by the health checker, it is never stored.
+	Health *Health `json:"health,omitempty" bson:"-" mapstructure:"-"`
+	// BreakerState of the circuit breaker of the ability. It is computed, it is never stored.
+	BreakerState string `json:"breaker_state,omitempty" bson:"-" mapstructure:"-"`
+}
+
+// Retry is the retry policy of the calls to an ability
+type Retry struct {
+	MaxRetries int `json:"max_retries" bson:"max_retries" mapstructure:"max_retries"`
+	// Backoff strategy between the retries: "constant" (default) or "exponential".
+	Backoff string `json:"backoff,omitempty" bson:"backoff,omitempty"`
+	// Delay before the first retry.
+	Delay Duration `json:"delay,omitempty" bson:"delay,omitempty"`
+	// MaxDelay caps the exponential backoff.
+	MaxDelay Duration `json:"max_delay,omitempty" bson:"max_delay,omitempty" mapstructure:"max_delay"`
+}
+
+// CircuitBreaker is the configuration of the circuit breaker of an ability
+type CircuitBreaker struct {
+	// FailureThreshold is the number of consecutive failed calls opening the circuit: failing on the transport, timing out
+	// or answering a 5xx status. The 4xx statuses are the answers of a working ability, they close the circuit.
+	FailureThreshold int `json:"failure_threshold" bson:"failure_threshold" mapstructure:"failure_threshold"`
+	// CoolDown is the duration the circuit stays open before letting a trial call through.
+	CoolDown Duration `json:"cool_down" bson:"cool_down" mapstructure:"cool_down"`
+}
+
+// Credentials of oratio for an ability, referencing a secret declared in the configuration, which holds its value
+// and the hosts it can be sent to
+type Credentials struct {
+	// Type of the credentials: "bearer" (a token in the Authorization header) or "api_key".
+	Type string `json:"type"`
+	// Header carrying the API key, "X-API-Key" if omitted.
+	Header string `json:"header,omitempty" bson:"header,omitempty"`
+	// Secret is the name of the secret in the configuration.
+	Secret string `json:"secret"`
+}
+
+// TLS is the configuration of the TLS connections to an ability, every file being a PEM file
+type TLS struct {
+	// CAFile is the CA trusted to check the certificate of the ability, the system ones if omitted.
+	CAFile string `json:"ca_file,omitempty" bson:"ca_file,omitempty" mapstructure:"ca_file"`
+	// CertFile and KeyFile are the client certificate and key presented to the ability, for mTLS.
+	CertFile string `json:"cert_file,omitempty" bson:"cert_file,omitempty" mapstructure:"cert_file"`
+	KeyFile  string `json:"key_file,omitempty" bson:"key_file,omitempty" mapstructure:"key_file"`
+	// ServerName expected in the certificate of the ability, the requested host if omitted.
+	ServerName string `json:"server_name,omitempty" bson:"server_name,omitempty" mapstructure:"server_name"`
+}
+
+// Limits caps the calls to an ability, the calls over the limits waiting for the queue timeout before being answered
+// that the ability is busy
+type Limits struct {
+	// MaxInFlight is the number of calls in flight at most, no cap if omitted.
+	MaxInFlight int `json:"max_in_flight,omitempty" bson:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
+	// Rate is the number of calls per second, no limit if omitted.
+	Rate float64 `json:"rate,omitempty" bson:"rate,omitempty"`
+	// Burst is the number of calls allowed at once over the rate (1 if omitted).
+	Burst int `json:"burst,omitempty" bson:"burst,omitempty"`
+	// QueueTimeout is how long a call waits for its turn, the call being answered right away if omitted.
+	QueueTimeout Duration `json:"queue_timeout,omitempty" bson:"queue_timeout,omitempty" mapstructure:"queue_timeout"`
+}
+
+// Manifest is the description of an ability by itself, fetched from the ability at its registration and refreshed since
+type Manifest struct {
+	// ProtocolVersion is the version of the protocol spoken by the ability at its registration.
+	ProtocolVersion int `json:"protocol_version" bson:"protocol_version" mapstructure:"protocol_version"`
+	// Utterances are example utterances of every intent.
+	Utterances map[string][]string `json:"utterances,omitempty" bson:"utterances,omitempty"`
+	// FetchedAt is the time the manifest was last fetched at.
+	FetchedAt time.Time `json:"fetched_at" bson:"fetched_at" mapstructure:"fetched_at"`
+	// Drift lists the differences between the last manifest fetched and the registration, which is not updated.
+	Drift []string `json:"drift,omitempty" bson:"drift,omitempty"`
+}
+
+// Instance is one of the servers serving an ability
+type Instance struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+	// URL is the base URL of the instance, taking precedence over the host and port.
+	URL string `json:"url,omitempty"`
+	// Weight of the instance with the weighted load balancing.
+	Weight int `json:"weight,omitempty"`
+	// Health of the instance. It is computed by the health checker, it is never stored.
+	Health *Health `json:"health,omitempty" bson:"-" mapstructure:"-"`
+}
+
+// Key identifies the ability among the registered ones: its name, and its version if it has one.
+func (a *Ability) Key() string {
+	if a.Version == "" {
+		return a.Name
+	}
+	return a.Name + "@" + a.Version
+}
+
+// AllInstances lists the instances serving the ability, starting with the one given by the URL or the host and port
+// if any.
+func (a *Ability) AllInstances() []Instance {
+	instances := make([]Instance, 0, len(a.Instances)+1)
+	if a.Host != "" || a.URL != "" {
+		instances = append(instances, Instance{Host: a.Host, Port: a.Port, URL: a.URL})
+	}
+	return append(instances, a.Instances...)
+}
+
+type HealthStatus string
+
+const (
+	HealthStatusUnknown   HealthStatus = "unknown"
+	HealthStatusHealthy   HealthStatus = "healthy"
+	HealthStatusUnhealthy HealthStatus = "unhealthy"
+)
+
+// Health is the state of an ability instance as seen by the last probes of the health checker
+type Health struct {
+	Status    HealthStatus `json:"status"`
+	CheckedAt time.Time    `json:"checked_at"`
+	Failures  int          `json:"failures,omitempty"`
+	Error     string       `json:"error,omitempty"`
 }
 
 // Abilities is the response body of the /api/v1/abilities endpoint (when no particular "from" query param is selected)
 type Abilities struct {
-	Cache    []*Ability `json:"cache"`
-	Database []*Ability `json:"database"`
-	Config   []*Ability `json:"config"`
+	Cache     []*Ability `json:"cache"`
+	Database  []*Ability `json:"database"`
+	Config    []*Ability `json:"config"`
+	Files     []*Ability `json:"files"`
+	InProcess []*Ability `json:"in_process"`
 }
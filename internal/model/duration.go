@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// Duration is a time.Duration written as a string like "1m30s" in the request/response bodies and in the configuration
+type Duration time.Duration
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	duration, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
@@ -0,0 +1,15 @@
+package model
+
+import "github.com/milobella/oratio/pkg/ability"
+
+// JobResult is the request body of the /api/v1/abilities/:name/jobs/:id endpoint: the final response of the job
+type JobResult = ability.Response
+
+// Notification is a response delivered to a device outside of its requests, on the /api/v1/notifications endpoint
+type Notification struct {
+	// Ability which answered
+	Ability string `json:"ability"`
+	// Job the response is the final one of
+	Job      string        `json:"job,omitempty"`
+	Response *TextResponse `json:"response"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// ShadowDiff is a difference between the responses of an ability and of its shadow to the same request.
+// It is the response body of the /api/v1/shadows/diffs endpoint
+type ShadowDiff struct {
+	Ability string    `json:"ability"`
+	Shadow  string    `json:"shadow"`
+	Time    time.Time `json:"time"`
+	// Text said by the user
+	Text string `json:"text"`
+	// Fields which differ, empty if the shadow failed
+	Fields []FieldDiff `json:"fields,omitempty"`
+	// Error of the shadow, if it failed
+	Error string `json:"error,omitempty"`
+}
+
+// FieldDiff is a field of the response which differs between an ability and its shadow
+type FieldDiff struct {
+	Field  string      `json:"field"`
+	Live   interface{} `json:"live"`
+	Shadow interface{} `json:"shadow"`
+}
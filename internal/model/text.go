@@ -17,3 +17,14 @@ type TextResponse struct {
 	AutoReprompt bool        `json:"auto_reprompt,omitempty"`
 	Context      interface{} `json:"context,omitempty"`
 }
+
+// NewTextResponse builds the response body from the response of the ability and its vocal rendering
+func NewTextResponse(vocal string, response *ability.Response) *TextResponse {
+	return &TextResponse{
+		Vocal:        vocal,
+		Visu:         response.Visu,
+		AutoReprompt: response.AutoReprompt,
+		Context:      response.Context,
+		Actions:      response.Actions,
+	}
+}
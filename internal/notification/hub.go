@@ -0,0 +1,97 @@
+package notification
+
+import (
+	"sync"
+
+	"github.com/milobella/oratio/internal/config"
+	"github.com/milobella/oratio/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultMaxPending = 100
+	// subscriptionBuffer is the number of notifications a listening device can lag behind before they are kept
+	// pending.
+	subscriptionBuffer = 16
+)
+
+// Hub delivers the notifications to the devices listening to them. The notifications of a device which is not
+// listening are kept pending, up to a maximum, and delivered when it listens again.
+type Hub struct {
+	mutex       sync.Mutex
+	maxPending  int
+	subscribers map[string][]chan *model.Notification
+	pending     map[string][]*model.Notification
+}
+
+func NewHub(conf config.Notifications) *Hub {
+	if conf.MaxPending <= 0 {
+		conf.MaxPending = defaultMaxPending
+	}
+	return &Hub{
+		maxPending:  conf.MaxPending,
+		subscribers: make(map[string][]chan *model.Notification),
+		pending:     make(map[string][]*model.Notification),
+	}
+}
+
+// Subscribe listens to the notifications of the device, starting with the pending ones. The returned function stops
+// the subscription, it must be called once the device stops listening.
+func (h *Hub) Subscribe(deviceID string) (<-chan *model.Notification, func()) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	pending := h.pending[deviceID]
+	delete(h.pending, deviceID)
+	subscription := make(chan *model.Notification, len(pending)+subscriptionBuffer)
+	for _, notification := range pending {
+		subscription <- notification
+	}
+	h.subscribers[deviceID] = append(h.subscribers[deviceID], subscription)
+
+	return subscription, func() { h.unsubscribe(deviceID, subscription) }
+}
+
+func (h *Hub) unsubscribe(deviceID string, subscription chan *model.Notification) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	subscriptions := h.subscribers[deviceID]
+	for i, s := range subscriptions {
+		if s == subscription {
+			subscriptions = append(subscriptions[:i], subscriptions[i+1:]...)
+			break
+		}
+	}
+	if len(subscriptions) == 0 {
+		delete(h.subscribers, deviceID)
+	} else {
+		h.subscribers[deviceID] = subscriptions
+	}
+}
+
+// Publish delivers the notification to every subscription of the device, or keeps it pending if there is none.
+func (h *Hub) Publish(deviceID string, notification *model.Notification) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	delivered := false
+	for _, subscription := range h.subscribers[deviceID] {
+		select {
+		case subscription <- notification:
+			delivered = true
+		default:
+			logrus.WithField("device", deviceID).Warn("The device is lagging behind its notifications.")
+		}
+	}
+	if delivered {
+		return
+	}
+
+	pending := append(h.pending[deviceID], notification)
+	if len(pending) > h.maxPending {
+		logrus.WithField("device", deviceID).Warn("Too many pending notifications, dropping the oldest one.")
+		pending = pending[1:]
+	}
+	h.pending[deviceID] = pending
+}
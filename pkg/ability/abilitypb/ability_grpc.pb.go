@@ -0,0 +1,107 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: ability.proto
+
+package abilitypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// AbilityClient is the client API for Ability service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AbilityClient interface {
+	// Resolve answers the request of the user.
+	Resolve(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
+}
+
+type abilityClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAbilityClient(cc grpc.ClientConnInterface) AbilityClient {
+	return &abilityClient{cc}
+}
+
+func (c *abilityClient) Resolve(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/milobella.oratio.ability.v1.Ability/Resolve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AbilityServer is the server API for Ability service.
+// All implementations must embed UnimplementedAbilityServer
+// for forward compatibility
+type AbilityServer interface {
+	// Resolve answers the request of the user.
+	Resolve(context.Context, *Request) (*Response, error)
+	mustEmbedUnimplementedAbilityServer()
+}
+
+// UnimplementedAbilityServer must be embedded to have forward compatible implementations.
+type UnimplementedAbilityServer struct {
+}
+
+func (UnimplementedAbilityServer) Resolve(context.Context, *Request) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resolve not implemented")
+}
+func (UnimplementedAbilityServer) mustEmbedUnimplementedAbilityServer() {}
+
+// UnsafeAbilityServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AbilityServer will
+// result in compilation errors.
+type UnsafeAbilityServer interface {
+	mustEmbedUnimplementedAbilityServer()
+}
+
+func RegisterAbilityServer(s grpc.ServiceRegistrar, srv AbilityServer) {
+	s.RegisterService(&Ability_ServiceDesc, srv)
+}
+
+func _Ability_Resolve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AbilityServer).Resolve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milobella.oratio.ability.v1.Ability/Resolve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AbilityServer).Resolve(ctx, req.(*Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Ability_ServiceDesc is the grpc.ServiceDesc for Ability service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Ability_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "milobella.oratio.ability.v1.Ability",
+	HandlerType: (*AbilityServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Resolve",
+			Handler:    _Ability_Resolve_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ability.proto",
+}
@@ -0,0 +1,4 @@
+// Package abilitypb : Protobuf definition of the requests and responses of the abilities served over gRPC
+package abilitypb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative ability.proto
@@ -0,0 +1,107 @@
+package ability
+
+import "fmt"
+
+// ActionType : Kind of action the device performs
+type ActionType string
+
+const (
+	// ActionMediaPlayback : Controls the media player of the device
+	ActionMediaPlayback ActionType = "media_playback"
+	// ActionDeviceCommand : Sends a command to an instrument of the device
+	ActionDeviceCommand ActionType = "device_command"
+)
+
+// Action : Something the device performs along with the vocal response. Only the field matching the type is given.
+type Action struct {
+	Version       int            `json:"version,omitempty"`
+	Type          ActionType     `json:"type"`
+	MediaPlayback *MediaPlayback `json:"media_playback,omitempty"`
+	DeviceCommand *DeviceCommand `json:"device_command,omitempty"`
+}
+
+// PlaybackCommand : Command of the media player
+type PlaybackCommand string
+
+const (
+	PlaybackPlay     PlaybackCommand = "play"
+	PlaybackPause    PlaybackCommand = "pause"
+	PlaybackResume   PlaybackCommand = "resume"
+	PlaybackStop     PlaybackCommand = "stop"
+	PlaybackNext     PlaybackCommand = "next"
+	PlaybackPrevious PlaybackCommand = "previous"
+)
+
+// MediaPlayback : Controls the media player of the device
+type MediaPlayback struct {
+	Command PlaybackCommand `json:"command"`
+	// Media : Media to play, with the play command only
+	Media *Media `json:"media,omitempty"`
+}
+
+// DeviceCommand : Command sent to an instrument of the device
+type DeviceCommand struct {
+	// Instrument : Kind of the instrument receiving the command (light, thermostat...)
+	Instrument string                 `json:"instrument"`
+	Name       string                 `json:"name"`
+	Params     map[string]interface{} `json:"params,omitempty"`
+}
+
+// DecodeActions : Typed actions of a response, nil if it has none, or a *SchemaError if they don't match the schema
+func DecodeActions(payload interface{}) ([]Action, error) {
+	if payload == nil {
+		return nil, nil
+	}
+	var actions []Action
+	if err := decodeStrictly("actions", payload, &actions); err != nil {
+		return nil, err
+	}
+	for i := range actions {
+		if err := actions[i].validate(fmt.Sprintf("actions[%d]", i)); err != nil {
+			return nil, err
+		}
+	}
+	return actions, nil
+}
+
+// Validate : Checks the action matches the schema, returning a *SchemaError otherwise
+func (a *Action) Validate() error {
+	return a.validate("action")
+}
+
+func (a *Action) validate(field string) error {
+	if err := checkVersion(field, a.Version); err != nil {
+		return err
+	}
+	switch a.Type {
+	case ActionMediaPlayback:
+		if a.MediaPlayback == nil {
+			return &SchemaError{Field: field + ".media_playback", Reason: "missing"}
+		}
+		return a.MediaPlayback.validate(field + ".media_playback")
+	case ActionDeviceCommand:
+		if a.DeviceCommand == nil {
+			return &SchemaError{Field: field + ".device_command", Reason: "missing"}
+		}
+		if err := required(field+".device_command.instrument", a.DeviceCommand.Instrument); err != nil {
+			return err
+		}
+		return required(field+".device_command.name", a.DeviceCommand.Name)
+	default:
+		return &SchemaError{Field: field + ".type", Reason: fmt.Sprintf("unknown type %q", a.Type)}
+	}
+}
+
+func (p *MediaPlayback) validate(field string) error {
+	switch p.Command {
+	case PlaybackPlay:
+		if p.Media == nil {
+			return &SchemaError{Field: field + ".media", Reason: "missing"}
+		}
+		return p.Media.validate(field + ".media")
+	case PlaybackPause, PlaybackResume, PlaybackStop, PlaybackNext, PlaybackPrevious:
+		return nil
+	default:
+		return &SchemaError{Field: field + ".command", Reason: fmt.Sprintf("unknown command %q", p.Command)}
+	}
+}
@@ -0,0 +1,112 @@
+package ability
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LoadBalancing : Policy balancing the calls between the instances of an ability
+type LoadBalancing string
+
+const (
+	// RoundRobin : Requests the instances one after the other
+	RoundRobin LoadBalancing = "round_robin"
+	// LeastInFlight : Requests the instance having the fewest calls in progress
+	LeastInFlight LoadBalancing = "least_in_flight"
+	// Weighted : Requests the instances randomly, in proportion to their weight
+	Weighted LoadBalancing = "weighted"
+)
+
+// LoadBalancer : State balancing the calls between the instances of an ability, its round robin position and the calls
+// in flight of every instance. It is meant to be shared by all the clients of the ability, as they are rebuilt.
+type LoadBalancer struct {
+	balancer balancer
+	mutex    sync.Mutex
+	// instances keeps the state of the instances by address and weight
+	instances map[Instance]*instance
+}
+
+// NewLoadBalancer : ctor of a load balancer following the policy (round robin if omitted)
+func NewLoadBalancer(policy LoadBalancing) *LoadBalancer {
+	return &LoadBalancer{balancer: newBalancer(policy), instances: make(map[Instance]*instance)}
+}
+
+// share returns the state kept for the instances, starting with the given one for the new instances. The instances
+// which are not given anymore are forgotten.
+func (b *LoadBalancer) share(instances []*instance) []*instance {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	shared := make([]*instance, 0, len(instances))
+	known := make(map[Instance]*instance, len(instances))
+	for _, i := range instances {
+		if existing, ok := b.instances[i.Instance]; ok {
+			i = existing
+		}
+		known[i.Instance] = i
+		shared = append(shared, i)
+	}
+	b.instances = known
+	return shared
+}
+
+// balancer orders the instances for a call: the first one is requested, the next ones are used to fail over.
+type balancer interface {
+	order(instances []*instance) []*instance
+}
+
+func newBalancer(policy LoadBalancing) balancer {
+	switch policy {
+	case LeastInFlight:
+		return leastInFlightBalancer{}
+	case Weighted:
+		return weightedBalancer{}
+	case RoundRobin, "":
+		return &roundRobinBalancer{}
+	default:
+		logrus.WithField("loadBalancing", policy).Warnf("Unknown load balancing policy. Default to %s", RoundRobin)
+		return &roundRobinBalancer{}
+	}
+}
+
+type roundRobinBalancer struct {
+	next uint64
+}
+
+func (b *roundRobinBalancer) order(instances []*instance) []*instance {
+	if len(instances) == 0 {
+		return instances
+	}
+	start := int((atomic.AddUint64(&b.next, 1) - 1) % uint64(len(instances)))
+	ordered := make([]*instance, 0, len(instances))
+	return append(append(ordered, instances[start:]...), instances[:start]...)
+}
+
+type leastInFlightBalancer struct{}
+
+func (leastInFlightBalancer) order(instances []*instance) []*instance {
+	ordered := append(make([]*instance, 0, len(instances)), instances...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return atomic.LoadInt64(&ordered[i].inFlight) < atomic.LoadInt64(&ordered[j].inFlight)
+	})
+	return ordered
+}
+
+type weightedBalancer struct{}
+
+// order draws the instances without replacement, each draw being proportional to the weights (Efraimidis-Spirakis).
+func (weightedBalancer) order(instances []*instance) []*instance {
+	keys := make(map[*instance]float64, len(instances))
+	for _, i := range instances {
+		keys[i] = math.Pow(rand.Float64(), 1/float64(i.Weight))
+	}
+	ordered := append(make([]*instance, 0, len(instances)), instances...)
+	sort.Slice(ordered, func(i, j int) bool {
+		return keys[ordered[i]] > keys[ordered[j]]
+	})
+	return ordered
+}
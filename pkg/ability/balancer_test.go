@@ -0,0 +1,48 @@
+package ability
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+var balancedInstances = []Instance{{Host: "clock-1", Port: 10300}, {Host: "clock-2", Port: 10300}, {Host: "clock-3", Port: 10300}}
+
+func TestLoadBalancerSharedByRebuiltClients(t *testing.T) {
+	loadBalancer := NewLoadBalancer(RoundRobin)
+	var got []string
+	for i := 0; i < len(balancedInstances)*2; i++ {
+		// A client is rebuilt for every call, as the ones of the abilities registered in the database.
+		c := NewBalancedClient("clock", balancedInstances, WithLoadBalancer(loadBalancer))
+		got = append(got, c.balancer.order(c.available())[0].Host)
+	}
+	want := []string{"clock-1", "clock-2", "clock-3", "clock-1", "clock-2", "clock-3"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("first instances = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestLoadBalancerSharesTheCallsInFlight(t *testing.T) {
+	loadBalancer := NewLoadBalancer(LeastInFlight)
+	busy := NewBalancedClient("clock", balancedInstances, WithLoadBalancer(loadBalancer))
+	atomic.AddInt64(&busy.instances[0].inFlight, 1)
+	atomic.AddInt64(&busy.instances[1].inFlight, 1)
+
+	c := NewBalancedClient("clock", balancedInstances, WithLoadBalancer(loadBalancer))
+	if first := c.balancer.order(c.available())[0]; first.Host != "clock-3" {
+		t.Errorf("first instance = %s, want the only one without call in flight", first.Host)
+	}
+}
+
+func TestLoadBalancerForgetsTheRemovedInstances(t *testing.T) {
+	loadBalancer := NewLoadBalancer(RoundRobin)
+	c := NewBalancedClient("clock", balancedInstances, WithLoadBalancer(loadBalancer))
+	atomic.AddInt64(&c.instances[2].inFlight, 1)
+
+	NewBalancedClient("clock", balancedInstances[:2], WithLoadBalancer(loadBalancer))
+	c = NewBalancedClient("clock", balancedInstances, WithLoadBalancer(loadBalancer))
+	if inFlight := atomic.LoadInt64(&c.instances[2].inFlight); inFlight != 0 {
+		t.Errorf("calls in flight of the instance added back = %d, want 0", inFlight)
+	}
+}
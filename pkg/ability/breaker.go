@@ -0,0 +1,120 @@
+package ability
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// BreakerState : State of a circuit breaker
+type BreakerState string
+
+const (
+	// BreakerClosed : The calls go through, the consecutive failures are counted
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen : The calls fail fast until the cool down is over
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen : A single trial call goes through, its result closes or reopens the circuit
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+// ErrCircuitOpen : The ability has not been requested because its circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreaker : Stops requesting an ability which keeps failing, until a cool down is over
+type CircuitBreaker struct {
+	name             string
+	failureThreshold int
+	coolDown         time.Duration
+
+	mutex    sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// NewCircuitBreaker : ctor of a breaker opening after failureThreshold consecutive failures, for coolDown
+func NewCircuitBreaker(name string, failureThreshold int, coolDown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		name:             name,
+		failureThreshold: failureThreshold,
+		coolDown:         coolDown,
+		state:            BreakerClosed,
+	}
+}
+
+// State : Current state of the circuit breaker
+func (b *CircuitBreaker) State() BreakerState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.currentState()
+}
+
+// currentState half opens the circuit once the cool down is over. The mutex must be held.
+func (b *CircuitBreaker) currentState() BreakerState {
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.coolDown {
+		b.setState(BreakerHalfOpen)
+	}
+	return b.state
+}
+
+// allow tells whether a call can go through, reserving the trial call when the circuit is half open.
+func (b *CircuitBreaker) allow() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	switch b.currentState() {
+	case BreakerOpen:
+		return false
+	case BreakerHalfOpen:
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record counts the failures of the ability: the transport failures, the timeouts and the 5xx statuses, as for the
+// retries. The other errors (a 4xx status answering a domain error, a local error) come with a working ability, they
+// count as successes. A call which didn't reach any instance, or which has been canceled by the caller, counts for
+// nothing.
+func (b *CircuitBreaker) record(err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.trial = false
+
+	if errors.Is(err, ErrNoAvailableInstance) || errors.Is(err, context.Canceled) {
+		return
+	}
+	if err == nil || !retryable(err) {
+		b.failures = 0
+		b.setState(BreakerClosed)
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.failureThreshold {
+		b.openedAt = time.Now()
+		b.setState(BreakerOpen)
+	}
+}
+
+// setState logs the transitions of the circuit. The mutex must be held.
+func (b *CircuitBreaker) setState(state BreakerState) {
+	if b.state == state {
+		return
+	}
+	logrus.
+		WithField("client", b.name).
+		WithField("from", b.state).
+		WithField("to", state).
+		WithField("failures", b.failures).
+		Warn("The circuit breaker changed state.")
+	b.state = state
+}
@@ -0,0 +1,109 @@
+package ability
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var (
+	errCallFailed  = &Error{Kind: KindTransport, Err: errors.New("call failed")}
+	errTimeout     = &Error{Kind: KindTimeout, Err: errors.New("deadline exceeded")}
+	errServer      = &Error{Kind: KindAbility, StatusCode: 503}
+	errDomain      = &Error{Kind: KindAbility, StatusCode: 404, Detail: ErrorDetail{Code: "NO_SHOWTIME"}}
+	errLocalSecret = errors.New("environment variable CLOCK_API_KEY is not set")
+)
+
+// coolDownOver makes the breaker's cool down over without waiting for it.
+func coolDownOver(b *CircuitBreaker) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.openedAt = time.Now().Add(-b.coolDown)
+}
+
+func TestCircuitBreakerOpens(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold int
+		calls     []error
+		want      BreakerState
+	}{
+		{"no call", 3, nil, BreakerClosed},
+		{"under the threshold", 3, []error{errCallFailed, errCallFailed}, BreakerClosed},
+		{"at the threshold", 3, []error{errCallFailed, errCallFailed, errCallFailed}, BreakerOpen},
+		{"success resets the failures", 3, []error{errCallFailed, errCallFailed, nil, errCallFailed, errCallFailed}, BreakerClosed},
+		{"threshold of one", 1, []error{errCallFailed}, BreakerOpen},
+		{"timeouts and 5xx statuses", 3, []error{errTimeout, errServer, errCallFailed}, BreakerOpen},
+		{"domain errors", 3, []error{errDomain, errDomain, errDomain, errDomain, errDomain}, BreakerClosed},
+		{"domain error resets the failures", 3, []error{errServer, errServer, errDomain, errServer, errServer}, BreakerClosed},
+		{"local errors", 3, []error{errLocalSecret, errLocalSecret, errLocalSecret}, BreakerClosed},
+		{"no available instance", 3, []error{errServer, errServer, ErrNoAvailableInstance, errServer}, BreakerOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewCircuitBreaker("clock", tt.threshold, time.Minute)
+			for _, err := range tt.calls {
+				if !b.allow() {
+					t.Fatalf("allow() = false before the circuit opened")
+				}
+				b.record(err)
+			}
+			if state := b.State(); state != tt.want {
+				t.Errorf("State() = %s, want %s", state, tt.want)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerFailsFastWhileOpen(t *testing.T) {
+	b := NewCircuitBreaker("clock", 1, time.Minute)
+	b.record(errCallFailed)
+	for i := 0; i < 3; i++ {
+		if b.allow() {
+			t.Fatalf("allow() = true while the circuit is open")
+		}
+	}
+	if state := b.State(); state != BreakerOpen {
+		t.Errorf("State() = %s, want %s", state, BreakerOpen)
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	tests := []struct {
+		name  string
+		trial error
+		want  BreakerState
+	}{
+		{"successful trial closes", nil, BreakerClosed},
+		{"failed trial reopens", errCallFailed, BreakerOpen},
+		{"domain error closes", errDomain, BreakerClosed},
+		{"trial without instance stays half open", ErrNoAvailableInstance, BreakerHalfOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewCircuitBreaker("clock", 2, time.Minute)
+			b.record(errCallFailed)
+			b.record(errCallFailed)
+			coolDownOver(b)
+			if state := b.State(); state != BreakerHalfOpen {
+				t.Fatalf("State() = %s after the cool down, want %s", state, BreakerHalfOpen)
+			}
+
+			if !b.allow() {
+				t.Fatalf("allow() = false for the trial call")
+			}
+			// Only a single trial call goes through while it is pending.
+			if b.allow() {
+				t.Fatalf("allow() = true for a second trial call")
+			}
+
+			b.record(tt.trial)
+			if state := b.State(); state != tt.want {
+				t.Errorf("State() = %s after the trial, want %s", state, tt.want)
+			}
+			if allowed := b.allow(); allowed != (tt.want != BreakerOpen) {
+				t.Errorf("allow() = %v after the trial", allowed)
+			}
+		})
+	}
+}
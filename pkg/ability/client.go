@@ -2,69 +2,431 @@ package ability
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
-// Client : Ability HTTP client
+// ErrNoAvailableInstance : None of the instances of the ability can be requested
+var ErrNoAvailableInstance = errors.New("no available instance")
+
+// Client : Ability client, over HTTP, gRPC or in process, balancing the calls between the instances of the ability
 type Client struct {
-	Host   string
-	Port   int
-	url    string
-	client http.Client
-	Name   string
+	Name string
+	// Version of the ability, empty if it is not versioned
+	Version   string
+	instances []*instance
+	balancer  balancer
+	// loadBalancer shares the state of the balancer and of the instances with the other clients of the ability
+	loadBalancer *LoadBalancer
+	filter       func(Instance) bool
+	breaker      *CircuitBreaker
+	limiter      *Limiter
+	retry        RetryPolicy
+	// capabilities the device must have to be served by the ability
+	capabilities []string
+	protocol     Protocol
+	// resolver answers the calls instead of the instances, for an in-process ability
+	resolver Resolver
+	// secret shared with the ability to sign the requests, no signature if empty
+	secret    []byte
+	tlsConfig *tls.Config
+	// headers sent with every call, along with the secretHeaders resolved at every call
+	headers       http.Header
+	secretHeaders []secretHeader
+	client        http.Client
+	// err the client has been configured with, failing all its calls
+	err error
+}
+
+// ClientOption : Optional configuration of a Client
+type ClientOption func(*Client)
+
+// WithLoadBalancing : Policy balancing the calls between the instances (round robin if omitted)
+func WithLoadBalancing(policy LoadBalancing) ClientOption {
+	return func(c *Client) {
+		c.balancer = newBalancer(policy)
+	}
+}
+
+// WithLoadBalancer : Load balancer shared with the other clients of the same ability, so that the calls keep being
+// balanced as the clients are rebuilt. It takes precedence over WithLoadBalancing.
+func WithLoadBalancer(loadBalancer *LoadBalancer) ClientOption {
+	return func(c *Client) {
+		c.loadBalancer = loadBalancer
+	}
+}
+
+// WithInstanceFilter : Predicate excluding some instances from the calls, the unhealthy ones for example
+func WithInstanceFilter(filter func(Instance) bool) ClientOption {
+	return func(c *Client) {
+		c.filter = filter
+	}
+}
+
+// WithCircuitBreaker : Breaker failing the calls fast while the ability keeps failing. It can be shared between
+// several clients of the same ability.
+func WithCircuitBreaker(breaker *CircuitBreaker) ClientOption {
+	return func(c *Client) {
+		c.breaker = breaker
+	}
+}
+
+// WithLimiter : Limiter capping the calls in flight and their rate. It should be shared between the clients of the same
+// ability.
+func WithLimiter(limiter *Limiter) ClientOption {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// WithTimeout : Timeout of every attempt to request the ability (no timeout if omitted)
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.client.Timeout = timeout
+	}
+}
+
+// WithRetryPolicy : Retries of the calls failing on the transport or with a 5xx status (no retry if omitted)
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithVersion : Version of the ability requested by the client
+func WithVersion(version string) ClientOption {
+	return func(c *Client) {
+		c.Version = version
+	}
+}
+
+// WithRequiredCapabilities : Capabilities the device must have to be served by the ability (screen, speaker, ...)
+func WithRequiredCapabilities(capabilities ...string) ClientOption {
+	return func(c *Client) {
+		c.capabilities = capabilities
+	}
+}
+
+// WithProtocol : Protocol the ability is requested with (HTTP if omitted)
+func WithProtocol(protocol Protocol) ClientOption {
+	return func(c *Client) {
+		if protocol != "" {
+			c.protocol = protocol
+		}
+	}
+}
+
+// WithSigningSecret : Secret shared with the ability to sign every request, so that it can check it comes from oratio
+// with VerifyRequest (no signature if omitted). Only the HTTP requests are signed.
+func WithSigningSecret(secret []byte) ClientOption {
+	return func(c *Client) {
+		c.secret = secret
+	}
+}
+
+// WithConfigurationError : Error the client has been configured with, a TLS configuration which can't be loaded for
+// example. The client is unavailable, its calls fail with the error rather than being sent with a weaker configuration.
+func WithConfigurationError(err error) ClientOption {
+	return func(c *Client) {
+		c.err = err
+	}
+}
+
+// NewClient : ctor of a client requesting a single instance
+func NewClient(host string, port int, name string, opts ...ClientOption) *Client {
+	return NewBalancedClient(name, []Instance{{Host: host, Port: port}}, opts...)
+}
+
+// NewBalancedClient : ctor of a client balancing the calls between several instances
+func NewBalancedClient(name string, instances []Instance, opts ...ClientOption) *Client {
+	c := &Client{
+		Name:      name,
+		instances: make([]*instance, 0, len(instances)),
+		balancer:  newBalancer(RoundRobin),
+		protocol:  HTTP,
+		headers:   make(http.Header),
+		client:    http.Client{},
+	}
+	for _, i := range instances {
+		c.instances = append(c.instances, newInstance(i))
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.loadBalancer != nil {
+		c.balancer = c.loadBalancer.balancer
+		c.instances = c.loadBalancer.share(c.instances)
+	}
+	return c
+}
+
+// Instances : The instances serving the ability
+func (c *Client) Instances() []Instance {
+	instances := make([]Instance, 0, len(c.instances))
+	for _, i := range c.instances {
+		instances = append(instances, i.Instance)
+	}
+	return instances
+}
+
+// Protocol : Protocol the ability is requested with
+func (c *Client) Protocol() Protocol {
+	return c.protocol
+}
+
+// Signed : Whether a secret is shared with the ability, signing the requests in both directions
+func (c *Client) Signed() bool {
+	return len(c.secret) > 0
+}
+
+// BreakerState : State of the circuit breaker of the ability, empty if it has none
+func (c *Client) BreakerState() BreakerState {
+	if c.breaker == nil {
+		return ""
+	}
+	return c.breaker.State()
+}
+
+// RequiredCapabilities : Capabilities the device must have to be served by the ability
+func (c *Client) RequiredCapabilities() []string {
+	return c.capabilities
+}
+
+// MissingCapabilities : Capabilities required by the ability that the device doesn't have
+func (c *Client) MissingCapabilities(device Device) []string {
+	var missing []string
+	for _, capability := range c.capabilities {
+		if !device.HasCapability(capability) {
+			missing = append(missing, capability)
+		}
+	}
+	return missing
 }
 
-// NewClient : ctor
-func NewClient(host string, port int, name string) *Client {
-	url := fmt.Sprintf("http://%s:%d", host, port)
-	return &Client{Host: host, Port: port, url: url, client: http.Client{}, Name: name}
+// Available : Whether at least one instance of the ability can be requested
+func (c *Client) Available() bool {
+	return c.err == nil && (c.resolver != nil || len(c.available()) > 0)
 }
 
-func (c Client) makeRequest(request Request) (response* Response, err error) {
-	endpoint := strings.Join([]string{c.url, "resolve"}, "/")
+func (c *Client) available() []*instance {
+	if c.filter == nil {
+		return c.instances
+	}
+	instances := make([]*instance, 0, len(c.instances))
+	for _, i := range c.instances {
+		if c.filter(i.Instance) {
+			instances = append(instances, i)
+		}
+	}
+	return instances
+}
+
+func (c *Client) makeRequest(ctx context.Context, inst *instance, request Request) (*Response, error) {
+	atomic.AddInt64(&inst.inFlight, 1)
+	defer atomic.AddInt64(&inst.inFlight, -1)
+
+	if c.protocol == GRPC {
+		return c.makeGRPCRequest(ctx, inst, request)
+	}
+	return c.makeHTTPRequest(ctx, inst, request)
+}
+
+func (c *Client) makeHTTPRequest(ctx context.Context, inst *instance, request Request) (response *Response, err error) {
+	endpoint := strings.Join([]string{inst.url, "resolve"}, "/")
 	postBody, err := json.Marshal(request)
 	if err != nil {
 		logrus.WithField("client", c.Name).Error(err)
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(postBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(postBody))
 	if err != nil {
 		logrus.WithField("client", c.Name).Error(err)
 		return nil, err
 	}
 
+	headers, err := c.outboundHeaders()
+	if err != nil {
+		logrus.WithField("client", c.Name).Error(err)
+		return nil, err
+	}
+	req.Header = headers
 	req.Header.Add("Content-Type", "application/json")
+	if len(c.secret) > 0 {
+		timestamp := time.Now().Unix()
+		req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(SignatureHeader, Sign(c.secret, timestamp, postBody))
+	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
+		err = newNetworkError(err)
 		logrus.WithField("client", c.Name).Error(err)
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	logrus.WithField("client", c.Name).WithField("status", resp.StatusCode).Infof("%s %s", req.Method, req.URL)
 
 	body, err := ioutil.ReadAll(resp.Body)
-	defer resp.Body.Close()
 	if err != nil {
+		err = newNetworkError(err)
 		logrus.WithField("client", c.Name).Error(err)
 		return nil, err
 	}
 
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		abilityErr := &Error{Kind: KindAbility, StatusCode: resp.StatusCode}
+		// The body is not necessarily an error envelope (a proxy error page for example), it is only a bonus.
+		var errorBody ErrorBody
+		if json.Unmarshal(body, &errorBody) == nil {
+			abilityErr.Detail = errorBody.Error
+		}
+		logrus.WithField("client", c.Name).Error(abilityErr)
+		return nil, abilityErr
+	}
+
 	err = json.Unmarshal(body, &response)
+	if err == nil && response == nil {
+		err = errors.New("empty response")
+	}
 	if err != nil {
+		err = &Error{Kind: KindPayload, Err: err}
 		logrus.WithField("client", c.Name).Error(err)
 		return nil, err
 	}
 	return
 }
 
-// CallAbility : Requests the ability
-func (c Client) CallAbility(request Request) (response* Response, err error) {
-	return c.makeRequest(request)
+// CallAbility : Requests the ability, failing fast if its circuit breaker is open, or if it is busy according to its
+// limiter
+func (c *Client) CallAbility(request Request) (*Response, error) {
+	return c.CallAbilityContext(context.Background(), request)
+}
+
+// CallAbilityContext : Requests the ability as CallAbility, the call and its retries being stopped when the context is
+// done. The context is given to the in-process abilities, and carries the trace of the call.
+func (c *Client) CallAbilityContext(ctx context.Context, request Request) (response *Response, err error) {
+	if c.err != nil {
+		err = &Error{Kind: KindUnavailable, Err: c.err}
+		logrus.WithField("client", c.Name).Error(err)
+		return nil, err
+	}
+	if c.limiter != nil {
+		release, err := c.limiter.acquire(ctx)
+		if err != nil {
+			logrus.WithField("client", c.Name).WithField("in_flight", c.limiter.InFlight()).Warn(err)
+			return nil, err
+		}
+		defer release()
+	}
+
+	if c.breaker == nil {
+		return c.callInstances(ctx, request)
+	}
+
+	if !c.breaker.allow() {
+		logrus.WithField("client", c.Name).WithField("breaker", c.breaker.State()).Error(ErrCircuitOpen)
+		return nil, ErrCircuitOpen
+	}
+	response, err = c.callInstances(ctx, request)
+	c.breaker.record(err)
+	return
+}
+
+// callInstances requests the ability while the calls fail on the transport or with a 5xx status: first failing over
+// the next instances without waiting, then retrying them according to the retry policy, until the context is done.
+func (c *Client) callInstances(ctx context.Context, request Request) (response *Response, err error) {
+	if c.resolver != nil {
+		return c.resolve(ctx, request)
+	}
+
+	instances := c.balancer.order(c.available())
+	if len(instances) == 0 {
+		logrus.WithField("client", c.Name).Error(ErrNoAvailableInstance)
+		return nil, ErrNoAvailableInstance
+	}
+
+	attempts := len(instances) + c.retry.MaxRetries
+	for attempt := 0; attempt < attempts; attempt++ {
+		if retry := attempt - len(instances) + 1; retry > 0 && !sleep(ctx, c.retry.delay(retry)) {
+			return
+		}
+		inst := instances[attempt%len(instances)]
+		if response, err = c.makeRequest(ctx, inst, request); err == nil || !retryable(err) || ctx.Err() != nil {
+			return
+		}
+		logrus.
+			WithField("client", c.Name).
+			WithField("instance", inst.url).
+			WithField("attempt", attempt+1).
+			WithField("attempts", attempts).
+			Warn("The call to the instance failed.")
+	}
+	return
+}
+
+// CheckHealth : Requests the health endpoint of every instance, an error means that one of them is not healthy.
+// With gRPC, the standard health service is requested instead of the endpoint.
+func (c *Client) CheckHealth(ctx context.Context, endpoint string) error {
+	for _, inst := range c.instances {
+		if err := c.checkHealth(ctx, inst, endpoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckInstanceHealth : Requests the health endpoint of one of the instances of the ability
+func (c *Client) CheckInstanceHealth(ctx context.Context, endpoint string, i Instance) error {
+	for _, inst := range c.instances {
+		if inst.Instance == i {
+			return c.checkHealth(ctx, inst, endpoint)
+		}
+	}
+	return fmt.Errorf("unknown instance %s", i.Address())
+}
+
+func (c *Client) checkHealth(ctx context.Context, inst *instance, endpoint string) error {
+	if c.err != nil {
+		return c.err
+	}
+	if c.protocol == GRPC {
+		return c.checkInstanceGRPCHealth(ctx, inst)
+	}
+	return c.checkInstanceHealth(ctx, inst, endpoint)
+}
+
+func (c *Client) checkInstanceHealth(ctx context.Context, inst *instance, endpoint string) error {
+	endpoint = strings.Join([]string{inst.url, strings.TrimPrefix(endpoint, "/")}, "/")
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	if req.Header, err = c.outboundHeaders(); err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	logrus.WithField("client", c.Name).WithField("status", resp.StatusCode).Debugf("%s %s", req.Method, req.URL)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("health endpoint answered with status %d", resp.StatusCode)
+	}
+	return nil
 }
@@ -0,0 +1,76 @@
+package ability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientWithConfigurationError(t *testing.T) {
+	errTLS := errors.New("no such CA file")
+	c := NewClient("localhost", 10300, "clock", WithConfigurationError(errTLS))
+
+	if c.Available() {
+		t.Errorf("Available() = true for a client with a configuration error")
+	}
+	if _, err := c.CallAbility(Request{}); !errors.Is(err, errTLS) || KindOf(err) != KindUnavailable {
+		t.Errorf("CallAbility() = %v, want an unavailable error wrapping %v", err, errTLS)
+	}
+	if err := c.CheckHealth(context.Background(), "health"); !errors.Is(err, errTLS) {
+		t.Errorf("CheckHealth() = %v, want %v", err, errTLS)
+	}
+}
+
+type contextKey struct{}
+
+func TestCallAbilityContextReachesTheResolver(t *testing.T) {
+	c := NewLocalClient("clock", ResolverFunc(func(ctx context.Context, request Request) (*Response, error) {
+		return NewSimpleResponse(fmt.Sprint(ctx.Value(contextKey{}))), nil
+	}))
+	ctx := context.WithValue(context.Background(), contextKey{}, "talk request")
+	response, err := c.CallAbilityContext(ctx, Request{})
+	if err != nil || response.Nlg.Sentence != "talk request" {
+		t.Errorf("CallAbilityContext() = %v, %v, want the resolver to get the context of the call", response, err)
+	}
+}
+
+func TestCallAbilityContextStopsTheRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	c := NewBalancedClient("clock", []Instance{{URL: server.URL}},
+		WithRetryPolicy(RetryPolicy{MaxRetries: 3, Delay: 10 * time.Second}),
+		WithCircuitBreaker(NewCircuitBreaker("clock", 1, time.Minute)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.CallAbilityContext(ctx, Request{}); err == nil {
+		t.Fatalf("CallAbilityContext() succeeded")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("CallAbilityContext() took %s, want it to stop waiting for the retry with the context", elapsed)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("the ability has been called %d times, want 1", got)
+	}
+
+	// A call canceled by the caller doesn't tell anything about the ability.
+	c = NewBalancedClient("clock", []Instance{{URL: server.URL}}, WithCircuitBreaker(NewCircuitBreaker("clock", 1, time.Minute)))
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.CallAbilityContext(canceled, Request{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("CallAbilityContext() = %v, want the call to be canceled", err)
+	}
+	if state := c.BreakerState(); state != BreakerClosed {
+		t.Errorf("BreakerState() = %s after a canceled call, want %s", state, BreakerClosed)
+	}
+}
@@ -0,0 +1,80 @@
+package ability
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Secret : Reference to a secret held by an environment variable or a file, resolved at every call so that it can
+// be rotated without rebuilding the client
+type Secret struct {
+	Env  string
+	File string
+}
+
+// Resolve : Value of the secret, from the environment variable if given, from the file otherwise
+func (s Secret) Resolve() (string, error) {
+	switch {
+	case s.Env != "":
+		value, ok := os.LookupEnv(s.Env)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s not set", s.Env)
+		}
+		return value, nil
+	case s.File != "":
+		value, err := ioutil.ReadFile(s.File)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(value)), nil
+	default:
+		return "", errors.New("secret with neither an environment variable nor a file")
+	}
+}
+
+// secretHeader : Header whose value is a secret, with an optional prefix ("Bearer " for example)
+type secretHeader struct {
+	name   string
+	prefix string
+	secret Secret
+}
+
+// WithHeaders : Static headers sent with every call, as gRPC metadata with the gRPC protocol
+func WithHeaders(headers map[string]string) ClientOption {
+	return func(c *Client) {
+		for name, value := range headers {
+			c.headers.Set(name, value)
+		}
+	}
+}
+
+// WithBearerToken : Token sent in the Authorization header of every call
+func WithBearerToken(token Secret) ClientOption {
+	return func(c *Client) {
+		c.secretHeaders = append(c.secretHeaders, secretHeader{name: "Authorization", prefix: "Bearer ", secret: token})
+	}
+}
+
+// WithAPIKey : Key sent in the given header of every call
+func WithAPIKey(header string, key Secret) ClientOption {
+	return func(c *Client) {
+		c.secretHeaders = append(c.secretHeaders, secretHeader{name: header, secret: key})
+	}
+}
+
+// outboundHeaders resolves the headers to send with a call, the static ones and the secret ones.
+func (c *Client) outboundHeaders() (http.Header, error) {
+	headers := c.headers.Clone()
+	for _, h := range c.secretHeaders {
+		value, err := h.secret.Resolve()
+		if err != nil {
+			return nil, fmt.Errorf("resolving the %s header: %w", h.name, err)
+		}
+		headers.Set(h.name, h.prefix+value)
+	}
+	return headers, nil
+}
@@ -2,7 +2,33 @@ package ability
 
 // Device information
 type Device struct {
+	// ID of the device, used to keep its conversations on the same version of an ability
+	ID string `json:"id,omitempty"`
 	// Some dynamic information sent with each request
 	State       map[string]interface{} `json:"state,omitempty"`
 	Instruments []interface{}          `json:"instruments,omitempty"`
+	// Capabilities of the device (screen, speaker, ...), on top of the kinds of its instruments
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// HasCapability : Whether the device declares the capability or has an instrument of this kind
+func (d Device) HasCapability(capability string) bool {
+	for _, c := range d.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	for _, instrument := range d.Instruments {
+		switch i := instrument.(type) {
+		case string:
+			if i == capability {
+				return true
+			}
+		case map[string]interface{}:
+			if kind, ok := i["kind"].(string); ok && kind == capability {
+				return true
+			}
+		}
+	}
+	return false
 }
@@ -0,0 +1,90 @@
+package ability
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+// ErrorBody : Envelope of the body an ability answers with, along with a non-2xx status
+type ErrorBody struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail : Details of the error an ability answers with
+type ErrorDetail struct {
+	// Code : Ability specific code of the error
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ErrorKind : Category of the failure of a call to an ability
+type ErrorKind string
+
+const (
+	// KindUnknown : The error doesn't come from the call to the ability
+	KindUnknown ErrorKind = "unknown"
+	// KindUnavailable : The ability has not been requested, its circuit is open or none of its instances is available
+	KindUnavailable ErrorKind = "unavailable"
+	// KindBusy : The ability has not been requested, it reached its limit of calls in flight or its rate limit
+	KindBusy ErrorKind = "busy"
+	// KindTimeout : The ability didn't answer in time
+	KindTimeout ErrorKind = "timeout"
+	// KindTransport : The ability couldn't be reached
+	KindTransport ErrorKind = "transport"
+	// KindAbility : The ability answered with a non-2xx status
+	KindAbility ErrorKind = "ability"
+	// KindPayload : The ability answered with a body which is not a valid response
+	KindPayload ErrorKind = "payload"
+)
+
+// Error : Failure of a call to an ability
+type Error struct {
+	Kind ErrorKind
+	// StatusCode : Status answered by the ability, the HTTP equivalent of its code with gRPC (KindAbility only)
+	StatusCode int
+	// Detail : Error details answered by the ability, if it answered with an ErrorBody (KindAbility only)
+	Detail ErrorDetail
+	Err    error
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Kind == KindAbility && e.Detail.Message != "":
+		return fmt.Sprintf("ability answered with status %d: %s (%s)", e.StatusCode, e.Detail.Message, e.Detail.Code)
+	case e.Kind == KindAbility:
+		return fmt.Sprintf("ability answered with status %d", e.StatusCode)
+	case e.Err != nil:
+		return fmt.Sprintf("%s error: %s", e.Kind, e.Err)
+	default:
+		return fmt.Sprintf("%s error", e.Kind)
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// KindOf : Category of an error returned by a Client
+func KindOf(err error) ErrorKind {
+	if errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrNoAvailableInstance) {
+		return KindUnavailable
+	}
+	if errors.Is(err, ErrBusy) {
+		return KindBusy
+	}
+	var abilityErr *Error
+	if errors.As(err, &abilityErr) {
+		return abilityErr.Kind
+	}
+	return KindUnknown
+}
+
+// newNetworkError categorizes an error which occurred while exchanging with the ability.
+func newNetworkError(err error) *Error {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return &Error{Kind: KindTimeout, Err: err}
+	}
+	return &Error{Kind: KindTransport, Err: err}
+}
@@ -0,0 +1,286 @@
+package ability
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/milobella/oratio/pkg/ability/abilitypb"
+	"github.com/milobella/oratio/pkg/anima"
+	"github.com/milobella/oratio/pkg/cerebro"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Protocol : How the ability is requested
+type Protocol string
+
+const (
+	// HTTP : JSON bodies over POST /resolve
+	HTTP Protocol = "http"
+	// GRPC : Protobuf messages over the Resolve method of the abilitypb.Ability service
+	GRPC Protocol = "grpc"
+)
+
+// grpcConns : Connections to the gRPC instances by target and TLS configuration, shared by the clients as they can be
+// rebuilt at every request
+var grpcConns = struct {
+	sync.Mutex
+	conns map[grpcConnKey]*grpc.ClientConn
+}{conns: make(map[grpcConnKey]*grpc.ClientConn)}
+
+type grpcConnKey struct {
+	target    string
+	secure    bool
+	tlsConfig *tls.Config
+}
+
+// grpcConn returns the connection to the instance, dialing it the first time. The dial doesn't wait for the
+// connection to be established, the errors come with the calls. The connection is secured if the client has a TLS
+// configuration or if the instance has an https URL.
+func (c *Client) grpcConn(inst *instance) (*grpc.ClientConn, error) {
+	target, err := inst.target()
+	if err != nil {
+		return nil, err
+	}
+	key := grpcConnKey{target: target, secure: c.tlsConfig != nil || inst.secure(), tlsConfig: c.tlsConfig}
+	grpcConns.Lock()
+	defer grpcConns.Unlock()
+	if conn, ok := grpcConns.conns[key]; ok {
+		return conn, nil
+	}
+	transportCredentials := insecure.NewCredentials()
+	if key.secure {
+		transportCredentials = credentials.NewTLS(c.tlsConfig)
+	}
+	conn, err := grpc.Dial(key.target, grpc.WithTransportCredentials(transportCredentials))
+	if err != nil {
+		return nil, err
+	}
+	grpcConns.conns[key] = conn
+	return conn, nil
+}
+
+func (c *Client) makeGRPCRequest(ctx context.Context, inst *instance, request Request) (*Response, error) {
+	conn, err := c.grpcConn(inst)
+	if err != nil {
+		err = &Error{Kind: KindTransport, Err: err}
+		logrus.WithField("client", c.Name).Error(err)
+		return nil, err
+	}
+	protoRequest, err := toProtoRequest(request)
+	if err != nil {
+		logrus.WithField("client", c.Name).Error(err)
+		return nil, err
+	}
+	headers, err := c.outboundHeaders()
+	if err != nil {
+		logrus.WithField("client", c.Name).Error(err)
+		return nil, err
+	}
+	ctx, cancel := c.callContext(metadataContext(ctx, headers))
+	defer cancel()
+
+	response, err := abilitypb.NewAbilityClient(conn).Resolve(ctx, protoRequest)
+
+	logrus.WithField("client", c.Name).WithField("code", status.Code(err)).Infof("gRPC %s/Resolve", conn.Target())
+
+	if err != nil {
+		err = newGRPCError(err)
+		logrus.WithField("client", c.Name).Error(err)
+		return nil, err
+	}
+	return fromProtoResponse(response), nil
+}
+
+func (c *Client) checkInstanceGRPCHealth(ctx context.Context, inst *instance) error {
+	conn, err := c.grpcConn(inst)
+	if err != nil {
+		return err
+	}
+	headers, err := c.outboundHeaders()
+	if err != nil {
+		return err
+	}
+	response, err := grpc_health_v1.NewHealthClient(conn).Check(metadataContext(ctx, headers), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+
+	logrus.WithField("client", c.Name).WithField("status", response.Status).Debugf("gRPC %s/Check", conn.Target())
+
+	if response.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return status.Errorf(codes.Unavailable, "health service answered with status %s", response.Status)
+	}
+	return nil
+}
+
+// metadataContext sends the headers as the metadata of the gRPC calls made with the context.
+func metadataContext(ctx context.Context, headers http.Header) context.Context {
+	md := metadata.MD{}
+	for name, values := range headers {
+		md.Append(name, values...)
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// callContext applies the timeout of the client, the HTTP client applying it by itself.
+func (c *Client) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.client.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.client.Timeout)
+}
+
+// grpcStatusCodes : HTTP equivalents of the gRPC codes, so that the errors are handled the same whatever the protocol
+var grpcStatusCodes = map[codes.Code]int{
+	codes.Canceled:           499,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// newGRPCError categorizes an error returned by a gRPC call: the unavailable and deadline exceeded codes are failures
+// of the transport, the others are answered by the ability.
+func newGRPCError(err error) *Error {
+	s := status.Convert(err)
+	switch s.Code() {
+	case codes.Unavailable:
+		return &Error{Kind: KindTransport, Err: err}
+	case codes.DeadlineExceeded:
+		return &Error{Kind: KindTimeout, Err: err}
+	}
+	statusCode, ok := grpcStatusCodes[s.Code()]
+	if !ok {
+		statusCode = http.StatusInternalServerError
+	}
+	return &Error{
+		Kind:       KindAbility,
+		StatusCode: statusCode,
+		Detail:     ErrorDetail{Code: s.Code().String(), Message: s.Message()},
+	}
+}
+
+// toProtoRequest converts the request, its free form fields through their JSON form so that any JSON serializable
+// value is accepted, as with the HTTP protocol.
+func toProtoRequest(request Request) (*abilitypb.Request, error) {
+	nlu := &abilitypb.Nlu{
+		BestIntent: request.Nlu.BestIntent,
+		Intents:    make([]*abilitypb.Intent, 0, len(request.Nlu.Intents)),
+		Entities:   make([]*abilitypb.Entity, 0, len(request.Nlu.Entities)),
+		Text:       request.Nlu.Text,
+	}
+	for _, intent := range request.Nlu.Intents {
+		nlu.Intents = append(nlu.Intents, &abilitypb.Intent{Label: intent.Label, Score: intent.Score})
+	}
+	for _, entity := range request.Nlu.Entities {
+		nlu.Entities = append(nlu.Entities, &abilitypb.Entity{Label: entity.Label, Text: entity.Text})
+	}
+
+	slotFilling, err := toProtoValue(request.Context.SlotFilling)
+	if err != nil {
+		return nil, err
+	}
+	device := &abilitypb.Device{
+		Id:           request.Device.ID,
+		Instruments:  make([]*structpb.Value, 0, len(request.Device.Instruments)),
+		Capabilities: request.Device.Capabilities,
+	}
+	if request.Device.State != nil {
+		device.State = &structpb.Struct{}
+		if err = toProtoJSON(request.Device.State, device.State); err != nil {
+			return nil, err
+		}
+	}
+	for _, instrument := range request.Device.Instruments {
+		value, err := toProtoValue(instrument)
+		if err != nil {
+			return nil, err
+		}
+		device.Instruments = append(device.Instruments, value)
+	}
+
+	return &abilitypb.Request{
+		Nlu:     nlu,
+		Context: &abilitypb.Context{LastAbility: request.Context.LastAbility, SlotFilling: slotFilling},
+		Device:  device,
+	}, nil
+}
+
+func fromProtoResponse(response *abilitypb.Response) *Response {
+	result := &Response{
+		Visu:         fromProtoValue(response.Visu),
+		Actions:      fromProtoValue(response.Actions),
+		AutoReprompt: response.AutoReprompt,
+		Context: Context{
+			LastAbility: response.GetContext().GetLastAbility(),
+			SlotFilling: fromProtoValue(response.GetContext().GetSlotFilling()),
+		},
+		Confidence: response.Confidence,
+	}
+	if nlg := response.Nlg; nlg != nil {
+		result.Nlg = anima.NLG{Sentence: nlg.Sentence}
+		for _, param := range nlg.Params {
+			result.Nlg.Params = append(result.Nlg.Params, anima.NLGParam{
+				Name:  param.Name,
+				Value: fromProtoValue(param.Value),
+				Type:  param.Type,
+			})
+		}
+	}
+	if redirect := response.Redirect; redirect != nil {
+		result.Redirect = &Redirect{Intent: redirect.Intent, Ability: redirect.Ability}
+		for _, entity := range redirect.Entities {
+			result.Redirect.Entities = append(result.Redirect.Entities, cerebro.Entity{Label: entity.Label, Text: entity.Text})
+		}
+	}
+	if job := response.Job; job != nil {
+		result.Job = &Job{ID: job.Id}
+	}
+	if cache := response.Cache; cache != nil {
+		result.Cache = &CachePolicy{TTL: int(cache.Ttl), State: cache.State}
+	}
+	return result
+}
+
+// toProtoValue converts a free form field, nil if the field is nil.
+func toProtoValue(value interface{}) (*structpb.Value, error) {
+	if value == nil {
+		return nil, nil
+	}
+	result := &structpb.Value{}
+	return result, toProtoJSON(value, result)
+}
+
+func toProtoJSON(value interface{}, message proto.Message) error {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return protojson.Unmarshal(bytes, message)
+}
+
+func fromProtoValue(value *structpb.Value) interface{} {
+	if value == nil {
+		return nil
+	}
+	return value.AsInterface()
+}
@@ -0,0 +1,87 @@
+package ability
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Instance : One of the servers serving an ability
+type Instance struct {
+	Host string
+	Port int
+	// URL : Base URL of the instance, with its scheme and path, taking precedence over the host and port
+	URL string
+	// Weight of the instance with the weighted load balancing (1 if omitted)
+	Weight int
+}
+
+// Address : The base URL of the instance if it has one, its host and port otherwise
+func (i Instance) Address() string {
+	if i.URL != "" {
+		return i.URL
+	}
+	return net.JoinHostPort(i.Host, strconv.Itoa(i.Port))
+}
+
+// ValidateURL : Checks that the base URL of an instance is an absolute http or https URL
+func ValidateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q in %s, http or https expected", u.Scheme, raw)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("no host in %s", raw)
+	}
+	return nil
+}
+
+// instance : Instance with the state needed to balance the calls
+type instance struct {
+	// inFlight is accessed atomically, it is kept first to be 64-bit aligned.
+	inFlight int64
+	Instance
+	url string
+}
+
+func newInstance(i Instance) *instance {
+	if i.Weight <= 0 {
+		i.Weight = 1
+	}
+	baseURL := strings.TrimSuffix(i.URL, "/")
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("http://%s:%d", i.Host, i.Port)
+	}
+	return &instance{Instance: i, url: baseURL}
+}
+
+// target is the host and port of the instance, the default port of the scheme of its URL if it has none.
+func (i *instance) target() (string, error) {
+	if i.URL == "" {
+		return net.JoinHostPort(i.Host, strconv.Itoa(i.Port)), nil
+	}
+	u, err := url.Parse(i.url)
+	switch {
+	case err != nil:
+		return "", err
+	case u.Hostname() == "":
+		return "", errors.New("no host in " + i.url)
+	case u.Port() != "":
+		return u.Host, nil
+	case u.Scheme == "https":
+		return net.JoinHostPort(u.Hostname(), "443"), nil
+	default:
+		return net.JoinHostPort(u.Hostname(), "80"), nil
+	}
+}
+
+// secure tells whether the instance is requested over TLS.
+func (i *instance) secure() bool {
+	return strings.HasPrefix(i.url, "https://")
+}
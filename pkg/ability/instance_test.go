@@ -0,0 +1,57 @@
+package ability
+
+import "testing"
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"http://clock:10300", false},
+		{"https://gateway.milobella.com/abilities/clock", false},
+		{"http://[::1]:10300", false},
+		{"http://[::1", true},
+		{"ftp://clock", true},
+		{"clock:10300", true},
+		{"/abilities/clock", true},
+		{"https://", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if err := ValidateURL(tt.url); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateURL() = %v, want an error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInstanceTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance Instance
+		want     string
+		wantErr  bool
+	}{
+		{"host and port", Instance{Host: "clock", Port: 10300}, "clock:10300", false},
+		{"URL with port", Instance{URL: "http://clock:10300/base"}, "clock:10300", false},
+		{"http URL", Instance{URL: "http://clock"}, "clock:80", false},
+		{"https URL", Instance{URL: "https://clock/"}, "clock:443", false},
+		{"invalid URL", Instance{URL: "http://[::1"}, "", true},
+		{"URL without host", Instance{URL: "clock"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newInstance(tt.instance).target()
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("target() = %q, %v, want %q (error: %v)", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGRPCClientWithInvalidURL(t *testing.T) {
+	c := NewBalancedClient("clock", []Instance{{URL: "http://[::1"}}, WithProtocol(GRPC))
+	if _, err := c.CallAbility(Request{}); err == nil {
+		t.Errorf("CallAbility() succeeded with an invalid URL")
+	}
+}
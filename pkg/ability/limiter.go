@@ -0,0 +1,93 @@
+package ability
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ErrBusy : The ability has too many calls in flight, or it has been called too often
+var ErrBusy = errors.New("ability busy")
+
+// Limiter : Caps the calls in flight and the rate of the calls to an ability. It is meant to be shared by all the
+// clients of the ability, so that a noisy device can't starve the other abilities.
+type Limiter struct {
+	name string
+	// slots holds a token for every call in flight, nil if they are not capped
+	slots chan struct{}
+	// rate of the calls, nil if it is not limited
+	rate *rate.Limiter
+	// queueTimeout is how long a call can wait for a slot or for its turn, before failing with ErrBusy
+	queueTimeout time.Duration
+}
+
+// NewLimiter : ctor of a limiter, letting at most maxInFlight calls in flight (no cap if zero) and callsPerSecond
+// calls per second with bursts of burst calls (no limit if zero). A call waits for queueTimeout at most before
+// failing with ErrBusy, failing right away if zero.
+func NewLimiter(name string, maxInFlight int, callsPerSecond float64, burst int, queueTimeout time.Duration) *Limiter {
+	l := &Limiter{name: name, queueTimeout: queueTimeout}
+	if maxInFlight > 0 {
+		l.slots = make(chan struct{}, maxInFlight)
+	}
+	if callsPerSecond > 0 {
+		if burst < 1 {
+			burst = 1
+		}
+		l.rate = rate.NewLimiter(rate.Limit(callsPerSecond), burst)
+	}
+	return l
+}
+
+// InFlight : Number of calls in flight
+func (l *Limiter) InFlight() int {
+	return len(l.slots)
+}
+
+// acquire waits for a slot and the turn of a call, the returned function releasing the slot once the call is done. It
+// fails with ErrBusy if the wait would exceed the queue timeout, or if the context is done before. The slot is taken
+// first, so that a call which gets none doesn't use up a turn of the rate.
+func (l *Limiter) acquire(ctx context.Context) (func(), error) {
+	ctx, cancel := context.WithTimeout(ctx, l.queueTimeout)
+	defer cancel()
+
+	release, err := l.acquireSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if l.rate != nil {
+		if l.queueTimeout <= 0 {
+			if !l.rate.Allow() {
+				release()
+				return nil, ErrBusy
+			}
+		} else if l.rate.Wait(ctx) != nil {
+			// Wait gives the turn back when it fails, the slot is given back here.
+			release()
+			return nil, ErrBusy
+		}
+	}
+	return release, nil
+}
+
+// acquireSlot waits for a slot for a call, if the calls in flight are capped.
+func (l *Limiter) acquireSlot(ctx context.Context) (func(), error) {
+	if l.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, nil
+	default:
+	}
+	if l.queueTimeout <= 0 {
+		return nil, ErrBusy
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, nil
+	case <-ctx.Done():
+		return nil, ErrBusy
+	}
+}
@@ -0,0 +1,132 @@
+package ability
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiterAcquire(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxInFlight  int
+		perSecond    float64
+		queueTimeout time.Duration
+		// held is the number of slots taken before the call, released after releaseAfter if set
+		held         int
+		releaseAfter time.Duration
+		// calls is the number of calls made before the one tested, releasing their slot right away
+		calls   int
+		wantErr error
+		// maxWait is the longest the call is expected to wait, minWait the shortest
+		minWait time.Duration
+		maxWait time.Duration
+	}{
+		{name: "no limit", wantErr: nil, maxWait: 20 * time.Millisecond},
+		{name: "free slot", maxInFlight: 1, wantErr: nil, maxWait: 20 * time.Millisecond},
+		{name: "no slot and no queue", maxInFlight: 1, held: 1, wantErr: ErrBusy, maxWait: 20 * time.Millisecond},
+		{name: "no slot before the queue timeout", maxInFlight: 1, held: 1, queueTimeout: 50 * time.Millisecond,
+			wantErr: ErrBusy, minWait: 40 * time.Millisecond, maxWait: 200 * time.Millisecond},
+		{name: "slot released within the queue timeout", maxInFlight: 1, held: 1, releaseAfter: 30 * time.Millisecond,
+			queueTimeout: time.Second, wantErr: nil, minWait: 20 * time.Millisecond, maxWait: 500 * time.Millisecond},
+		{name: "turn available", perSecond: 1, wantErr: nil, maxWait: 20 * time.Millisecond},
+		{name: "no turn and no queue", perSecond: 1, calls: 1, wantErr: ErrBusy, maxWait: 20 * time.Millisecond},
+		{name: "turn within the queue timeout", perSecond: 20, calls: 1, queueTimeout: time.Second,
+			wantErr: nil, minWait: 30 * time.Millisecond, maxWait: 500 * time.Millisecond},
+		// The rate limiter knows the turn comes too late, the call fails without waiting.
+		{name: "turn after the queue timeout", perSecond: 1, calls: 1, queueTimeout: 50 * time.Millisecond,
+			wantErr: ErrBusy, maxWait: 20 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLimiter("test", tt.maxInFlight, tt.perSecond, 1, tt.queueTimeout)
+			for i := 0; i < tt.calls; i++ {
+				release, err := l.acquire(context.Background())
+				if err != nil {
+					t.Fatalf("acquire() #%d error = %v", i, err)
+				}
+				release()
+			}
+			for i := 0; i < tt.held; i++ {
+				l.slots <- struct{}{}
+			}
+			if tt.releaseAfter > 0 {
+				time.AfterFunc(tt.releaseAfter, func() { <-l.slots })
+			}
+
+			start := time.Now()
+			release, err := l.acquire(context.Background())
+			elapsed := time.Since(start)
+			if err != tt.wantErr {
+				t.Fatalf("acquire() error = %v, want %v", err, tt.wantErr)
+			}
+			if elapsed < tt.minWait || elapsed > tt.maxWait {
+				t.Errorf("acquire() waited %v, want between %v and %v", elapsed, tt.minWait, tt.maxWait)
+			}
+			if err == nil {
+				release()
+			}
+		})
+	}
+}
+
+func TestLimiterKeepsTheTurnWithoutASlot(t *testing.T) {
+	tests := []struct {
+		name         string
+		queueTimeout time.Duration
+	}{
+		{"no queue", 0},
+		{"queue", 20 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A single turn per minute: the call getting no slot must not use it up.
+			l := NewLimiter("test", 1, 1.0/60, 1, tt.queueTimeout)
+			l.slots <- struct{}{}
+			if _, err := l.acquire(context.Background()); err != ErrBusy {
+				t.Fatalf("acquire() without slot error = %v, want %v", err, ErrBusy)
+			}
+			<-l.slots
+
+			release, err := l.acquire(context.Background())
+			if err != nil {
+				t.Fatalf("acquire() after the slot is freed error = %v, want the turn kept", err)
+			}
+			release()
+			if l.InFlight() != 0 {
+				t.Errorf("InFlight() = %d after release, want 0", l.InFlight())
+			}
+		})
+	}
+}
+
+func TestLimiterReleasesTheSlotWithoutATurn(t *testing.T) {
+	l := NewLimiter("test", 1, 1.0/60, 1, 0)
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+	release()
+
+	if _, err = l.acquire(context.Background()); err != ErrBusy {
+		t.Fatalf("acquire() without turn error = %v, want %v", err, ErrBusy)
+	}
+	if l.InFlight() != 0 {
+		t.Errorf("InFlight() = %d after a call without turn, want 0", l.InFlight())
+	}
+}
+
+func TestLimiterAcquireCanceled(t *testing.T) {
+	l := NewLimiter("test", 1, 0, 1, time.Second)
+	l.slots <- struct{}{}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	if _, err := l.acquire(ctx); err != ErrBusy {
+		t.Fatalf("acquire() error = %v, want %v", err, ErrBusy)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("acquire() waited %v after the cancellation, want it to stop", elapsed)
+	}
+}
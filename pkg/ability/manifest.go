@@ -0,0 +1,95 @@
+package ability
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ProtocolVersion : Version of the protocol between oratio and the abilities described by this package
+const ProtocolVersion = 1
+
+// Manifest : Description of an ability by itself, served at its manifest endpoint, so that it can be registered with
+// its URL only
+type Manifest struct {
+	Name    string   `json:"name"`
+	Intents []string `json:"intents"`
+	// Utterances : Example utterances of every intent
+	Utterances map[string][]string `json:"utterances,omitempty"`
+	// RequiredCapabilities : Capabilities the device must have to be served by the ability
+	RequiredCapabilities []string `json:"required_capabilities,omitempty"`
+	// ProtocolVersion : Version of the protocol the ability speaks, this package's one if omitted
+	ProtocolVersion int `json:"protocol_version,omitempty"`
+}
+
+// FetchManifest : Requests the manifest endpoint of the instances until one of them answers. The manifest is only
+// served over HTTP.
+func (c *Client) FetchManifest(ctx context.Context, endpoint string) (*Manifest, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.protocol != HTTP {
+		return nil, fmt.Errorf("no manifest over %s", c.protocol)
+	}
+	if len(c.instances) == 0 {
+		return nil, ErrNoAvailableInstance
+	}
+
+	var err error
+	for _, inst := range c.instances {
+		var manifest *Manifest
+		if manifest, err = c.fetchManifest(ctx, inst, endpoint); err == nil {
+			return manifest, nil
+		}
+		logrus.WithField("client", c.Name).WithField("instance", inst.url).WithError(err).Warn("Error fetching the manifest.")
+	}
+	return nil, err
+}
+
+func (c *Client) fetchManifest(ctx context.Context, inst *instance, endpoint string) (*Manifest, error) {
+	endpoint = strings.Join([]string{inst.url, strings.TrimPrefix(endpoint, "/")}, "/")
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if req.Header, err = c.outboundHeaders(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	logrus.WithField("client", c.Name).WithField("status", resp.StatusCode).Debugf("%s %s", req.Method, req.URL)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("manifest endpoint answered with status %d", resp.StatusCode)
+	}
+	manifest := &Manifest{}
+	if err = json.NewDecoder(resp.Body).Decode(manifest); err != nil {
+		return nil, err
+	}
+	return manifest, manifest.validate()
+}
+
+func (m *Manifest) validate() error {
+	if m.ProtocolVersion == 0 {
+		m.ProtocolVersion = ProtocolVersion
+	}
+	switch {
+	case m.Name == "":
+		return errors.New("manifest without name")
+	case len(m.Intents) == 0:
+		return errors.New("manifest without intents")
+	case m.ProtocolVersion < 0 || m.ProtocolVersion > ProtocolVersion:
+		return fmt.Errorf("unsupported protocol version %d, the latest one being %d", m.ProtocolVersion, ProtocolVersion)
+	}
+	return nil
+}
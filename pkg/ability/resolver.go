@@ -0,0 +1,113 @@
+package ability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// InProcess : The ability is a Resolver running in the oratio process, it is requested with no network hop
+const InProcess Protocol = "in_process"
+
+// Resolver : Ability running in process, answering the same requests and responses as the remote abilities
+type Resolver interface {
+	Resolve(ctx context.Context, request Request) (*Response, error)
+}
+
+// ResolverFunc : Adapter to use an ordinary function as a Resolver
+type ResolverFunc func(ctx context.Context, request Request) (*Response, error)
+
+// Resolve : Calls f(ctx, request)
+func (f ResolverFunc) Resolve(ctx context.Context, request Request) (*Response, error) {
+	return f(ctx, request)
+}
+
+// Registration : In-process ability registered with Register
+type Registration struct {
+	Name     string
+	Intents  []string
+	Resolver Resolver
+	Options  []ClientOption
+}
+
+var registry = struct {
+	sync.RWMutex
+	registrations map[string]Registration
+}{registrations: make(map[string]Registration)}
+
+// Register : Makes an in-process ability available to the ability service, for the given intents. It is meant to be
+// called from the init function of the package implementing the ability, and panics if the name is already registered.
+// The options apply to the client of the ability (timeout, circuit breaker, required capabilities...).
+func Register(name string, intents []string, resolver Resolver, opts ...ClientOption) {
+	registry.Lock()
+	defer registry.Unlock()
+	if resolver == nil {
+		panic("ability: Register resolver is nil")
+	}
+	if _, duplicate := registry.registrations[name]; duplicate {
+		panic("ability: Register called twice for ability " + name)
+	}
+	registry.registrations[name] = Registration{Name: name, Intents: intents, Resolver: resolver, Options: opts}
+}
+
+// Registered : The in-process abilities registered with Register, sorted by name
+func Registered() []Registration {
+	registry.RLock()
+	defer registry.RUnlock()
+	registrations := make([]Registration, 0, len(registry.registrations))
+	for _, registration := range registry.registrations {
+		registrations = append(registrations, registration)
+	}
+	sort.Slice(registrations, func(i, j int) bool { return registrations[i].Name < registrations[j].Name })
+	return registrations
+}
+
+// NewLocalClient : ctor of a client calling an in-process ability
+func NewLocalClient(name string, resolver Resolver, opts ...ClientOption) *Client {
+	c := NewBalancedClient(name, nil, opts...)
+	c.resolver = resolver
+	c.protocol = InProcess
+	return c
+}
+
+// resolve calls the in-process ability, its errors being categorized as the ones of the remote abilities.
+func (c *Client) resolve(ctx context.Context, request Request) (*Response, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	response, err := c.resolver.Resolve(ctx, request)
+
+	logrus.WithField("client", c.Name).Info("Resolve in process")
+
+	if err == nil && response == nil {
+		err = &Error{Kind: KindPayload, Err: errors.New("empty response")}
+	}
+	if err != nil {
+		err = newResolverError(err)
+		logrus.WithField("client", c.Name).Error(err)
+		return nil, err
+	}
+	return response, nil
+}
+
+// newResolverError categorizes an error returned by a Resolver. It can return an *Error to choose its category,
+// the other errors being internal errors of the ability, unless the deadline of the call exceeded.
+func newResolverError(err error) *Error {
+	var abilityErr *Error
+	if errors.As(err, &abilityErr) {
+		return abilityErr
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &Error{Kind: KindTimeout, Err: err}
+	}
+	return &Error{
+		Kind:       KindAbility,
+		StatusCode: http.StatusInternalServerError,
+		Detail:     ErrorDetail{Message: fmt.Sprint(err)},
+	}
+}
@@ -1,6 +1,9 @@
 package ability
 
-import "github.com/milobella/oratio/pkg/anima"
+import (
+	"github.com/milobella/oratio/pkg/anima"
+	"github.com/milobella/oratio/pkg/cerebro"
+)
 
 type Response struct {
 	Nlg          anima.NLG   `json:"nlg,omitempty"`
@@ -8,6 +11,49 @@ type Response struct {
 	Actions      interface{} `json:"actions,omitempty"`
 	AutoReprompt bool        `json:"auto_reprompt,omitempty"`
 	Context      Context     `json:"context,omitempty"`
+	// Confidence the ability has in its own response, used to arbitrate between competing abilities.
+	Confidence float32 `json:"confidence,omitempty"`
+	// Redirect forwards the turn to another intent or ability, which will answer instead.
+	Redirect *Redirect `json:"redirect,omitempty"`
+	// Job tells that the response is only an acknowledgement, the final one being delivered later.
+	Job *Job `json:"job,omitempty"`
+	// Cache lets oratio answer the next identical requests with this response, without requesting the ability.
+	Cache *CachePolicy `json:"cache,omitempty"`
+}
+
+// CachePolicy tells how long a response can be reused, and for which requests. The requests are identical when they
+// have the same intent, entities, device capabilities, and the same values for the selected keys of the device state.
+type CachePolicy struct {
+	// TTL of the response, in seconds.
+	TTL int `json:"ttl"`
+	// State lists the keys of the device state the response depends on.
+	State []string `json:"state,omitempty"`
+}
+
+// Job is a long-running work an ability keeps doing after having acknowledged the request. The ability delivers the
+// final response by posting it to the jobs endpoint of oratio, which forwards it to the device.
+type Job struct {
+	// ID of the job, unique for the ability.
+	ID string `json:"id"`
+}
+
+// Redirect asks oratio to forward the turn to another intent or ability in the same request.
+// The context of the redirecting response is forwarded along.
+type Redirect struct {
+	// Intent to forward the turn to, replacing the intents of the NLU.
+	Intent string `json:"intent,omitempty"`
+	// Ability to forward the turn to, it takes precedence over the intent to resolve the ability to request.
+	Ability string `json:"ability,omitempty"`
+	// Entities injected in the NLU of the forwarded request.
+	Entities []cerebro.Entity `json:"entities,omitempty"`
+}
+
+// Target : The ability or intent the turn is forwarded to
+func (r *Redirect) Target() string {
+	if r.Ability != "" {
+		return r.Ability
+	}
+	return r.Intent
 }
 
 func NewSimpleResponse(text string) *Response {
@@ -0,0 +1,70 @@
+package ability
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Backoff : Strategy computing the delay before retrying a call
+type Backoff string
+
+const (
+	// ConstantBackoff : Waits the same delay before every retry
+	ConstantBackoff Backoff = "constant"
+	// ExponentialBackoff : Doubles the delay at every retry, up to the max delay
+	ExponentialBackoff Backoff = "exponential"
+)
+
+// RetryPolicy : How the calls failing on the transport or with a 5xx status are retried
+type RetryPolicy struct {
+	MaxRetries int
+	Backoff    Backoff
+	// Delay : Delay before the first retry
+	Delay time.Duration
+	// MaxDelay : Cap of the exponential backoff (no cap if omitted)
+	MaxDelay time.Duration
+}
+
+// delay computes the delay before the given retry, starting from 1.
+func (p RetryPolicy) delay(retry int) time.Duration {
+	if p.Backoff != ExponentialBackoff || retry <= 1 {
+		return p.Delay
+	}
+	delay := p.Delay
+	for i := 1; i < retry; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	return delay
+}
+
+// sleep waits for the delay, unless the context is done before. It tells whether the delay is over.
+func sleep(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// retryable tells whether a failed call is worth retrying: the transport failed or the ability answered a 5xx status.
+func retryable(err error) bool {
+	var abilityErr *Error
+	if !errors.As(err, &abilityErr) {
+		return false
+	}
+	switch abilityErr.Kind {
+	case KindTimeout, KindTransport:
+		return true
+	case KindAbility:
+		return abilityErr.StatusCode >= 500
+	default:
+		return false
+	}
+}
@@ -0,0 +1,155 @@
+package ability
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		want   []time.Duration
+	}{
+		{
+			name:   "constant",
+			policy: RetryPolicy{Backoff: ConstantBackoff, Delay: 100 * time.Millisecond},
+			want:   []time.Duration{100 * time.Millisecond, 100 * time.Millisecond, 100 * time.Millisecond},
+		},
+		{
+			name:   "constant by default",
+			policy: RetryPolicy{Delay: 100 * time.Millisecond, MaxDelay: 50 * time.Millisecond},
+			want:   []time.Duration{100 * time.Millisecond, 100 * time.Millisecond},
+		},
+		{
+			name:   "exponential",
+			policy: RetryPolicy{Backoff: ExponentialBackoff, Delay: 100 * time.Millisecond},
+			want:   []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond},
+		},
+		{
+			name:   "exponential capped",
+			policy: RetryPolicy{Backoff: ExponentialBackoff, Delay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond},
+			want:   []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond},
+		},
+		{
+			name:   "exponential capped exactly",
+			policy: RetryPolicy{Backoff: ExponentialBackoff, Delay: 100 * time.Millisecond, MaxDelay: 200 * time.Millisecond},
+			want:   []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name:   "no delay",
+			policy: RetryPolicy{Backoff: ExponentialBackoff},
+			want:   []time.Duration{0, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, want := range tt.want {
+				if got := tt.policy.delay(i + 1); got != want {
+					t.Errorf("delay(%d) = %s, want %s", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"timeout", &Error{Kind: KindTimeout}, true},
+		{"transport", &Error{Kind: KindTransport}, true},
+		{"5xx status", &Error{Kind: KindAbility, StatusCode: 503}, true},
+		{"4xx status", &Error{Kind: KindAbility, StatusCode: 400}, false},
+		{"invalid payload", &Error{Kind: KindPayload}, false},
+		{"open circuit", ErrCircuitOpen, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryable(tt.err); got != tt.want {
+				t.Errorf("retryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallInstancesRetries(t *testing.T) {
+	tests := []struct {
+		name string
+		// statuses answered by the ability, one per call, the last one being repeated
+		statuses  []int
+		wantCalls int
+		wantKind  ErrorKind
+	}{
+		{"success", []int{http.StatusOK}, 1, ""},
+		{"5xx then success", []int{http.StatusServiceUnavailable, http.StatusOK}, 2, ""},
+		{"5xx until the retries are exhausted", []int{http.StatusInternalServerError}, 3, KindAbility},
+		{"4xx not retried", []int{http.StatusNotFound, http.StatusOK}, 1, KindAbility},
+		{"5xx then 4xx", []int{http.StatusBadGateway, http.StatusBadRequest, http.StatusOK}, 2, KindAbility},
+		{"timeout then success", []int{0, http.StatusOK}, 2, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := int(atomic.AddInt32(&calls, 1)) - 1
+				status := tt.statuses[len(tt.statuses)-1]
+				if call < len(tt.statuses) {
+					status = tt.statuses[call]
+				}
+				if status == 0 {
+					// Answers after the timeout of the client.
+					time.Sleep(200 * time.Millisecond)
+					status = http.StatusOK
+				}
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(`{"nlg": {"sentence": "It is noon."}}`))
+			}))
+			defer server.Close()
+
+			c := NewBalancedClient("clock", []Instance{{URL: server.URL}},
+				WithTimeout(100*time.Millisecond),
+				WithRetryPolicy(RetryPolicy{MaxRetries: 2, Delay: time.Millisecond}))
+			_, err := c.callInstances(context.Background(), Request{})
+			if err == nil && tt.wantKind != "" {
+				t.Errorf("callInstances() succeeded, want a %q error", tt.wantKind)
+			} else if err != nil && KindOf(err) != tt.wantKind {
+				t.Errorf("callInstances() = %v, want a %q error", err, tt.wantKind)
+			}
+			if got := int(atomic.LoadInt32(&calls)); got != tt.wantCalls {
+				t.Errorf("the ability has been called %d times, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCallInstancesFailsOverTransportErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"nlg": {"sentence": "It is noon."}}`))
+	}))
+	defer server.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	c := NewBalancedClient("clock", []Instance{{URL: down.URL}, {URL: server.URL}})
+	if _, err := c.callInstances(context.Background(), Request{}); err != nil {
+		t.Fatalf("callInstances() = %v, want the call to fail over the instance down", err)
+	}
+
+	// Without any other instance, the transport failure is retried according to the policy only.
+	c = NewBalancedClient("clock", []Instance{{URL: down.URL}}, WithRetryPolicy(RetryPolicy{MaxRetries: 1}))
+	if _, err := c.callInstances(context.Background(), Request{}); KindOf(err) != KindTransport {
+		t.Errorf("callInstances() = %v, want a transport error", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("the instance up has been called %d times, want 1", got)
+	}
+}
@@ -0,0 +1,61 @@
+package ability
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// SchemaVersion : Version of the schema of the visu and the actions described by this package. A payload without
+// version is considered of this version.
+const SchemaVersion = 1
+
+// SchemaError : A visu or an action which doesn't match the schema
+type SchemaError struct {
+	// Field : Path of the invalid field, "visu.card.title" for example
+	Field  string
+	Reason string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+// decodeStrictly decodes the free form payload, as received in the response, into the typed model. The unknown
+// fields are rejected, so that a typo doesn't go unnoticed.
+func decodeStrictly(field string, payload interface{}, model interface{}) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return &SchemaError{Field: field, Reason: err.Error()}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(model); err != nil {
+		return &SchemaError{Field: field, Reason: err.Error()}
+	}
+	return nil
+}
+
+func checkVersion(field string, version int) error {
+	if version < 0 || version > SchemaVersion {
+		return &SchemaError{Field: field + ".version", Reason: fmt.Sprintf("unsupported version %d", version)}
+	}
+	return nil
+}
+
+func required(field string, value string) error {
+	if value == "" {
+		return &SchemaError{Field: field, Reason: "missing"}
+	}
+	return nil
+}
+
+// ValidateResponse : Checks the visu and the actions of the response match the schema, returning a *SchemaError
+// otherwise
+func ValidateResponse(response *Response) error {
+	if _, err := DecodeVisu(response.Visu); err != nil {
+		return err
+	}
+	_, err := DecodeActions(response.Actions)
+	return err
+}
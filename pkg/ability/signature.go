@@ -0,0 +1,125 @@
+package ability
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// TimestampHeader : Header carrying the Unix time, in seconds, at which oratio signed the request
+	TimestampHeader = "X-Oratio-Timestamp"
+	// SignatureHeader : Header carrying the signature of the request, as "sha256=" followed by the hexadecimal HMAC
+	SignatureHeader = "X-Oratio-Signature"
+	// DefaultSignatureTolerance : Maximum age of a signed request accepted by VerifyRequest, to prevent replays
+	DefaultSignatureTolerance = 5 * time.Minute
+
+	signaturePrefix = "sha256="
+)
+
+var (
+	// ErrMissingSignature : The request is not signed
+	ErrMissingSignature = errors.New("missing signature")
+	// ErrInvalidSignature : The signature doesn't match the request, it has not been signed with the shared secret
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrExpiredSignature : The request has been signed too long ago, or in the future
+	ErrExpiredSignature = errors.New("expired signature")
+	// ErrNoSecret : No secret is shared with the ability, its requests can't be authenticated
+	ErrNoSecret = errors.New("no secret shared with the ability")
+)
+
+// Sign : Signature of the body sent at the timestamp, the HMAC-SHA256 of "<timestamp>.<body>" with the shared secret
+func Sign(secret []byte, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify : Checks the signature of the body sent at the timestamp, both given as sent in the headers, and that it has
+// been signed within the tolerance (no check of the age if the tolerance is zero)
+func Verify(secret []byte, timestamp string, signature string, body []byte, tolerance time.Duration) error {
+	if timestamp == "" || signature == "" {
+		return ErrMissingSignature
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, seconds, body))) {
+		return ErrInvalidSignature
+	}
+	if age := time.Since(time.Unix(seconds, 0)); tolerance > 0 && (age > tolerance || age < -tolerance) {
+		return ErrExpiredSignature
+	}
+	return nil
+}
+
+// SignRequest : Signs the request with the shared secret, as oratio signs its requests to the abilities. An ability
+// signs this way the requests it sends to oratio, the final responses of its jobs for example. The body is read and
+// restored.
+func SignRequest(r *http.Request, secret []byte) error {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(r.Body); err != nil {
+			return err
+		}
+		_ = r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	timestamp := time.Now().Unix()
+	r.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	r.Header.Set(SignatureHeader, Sign(secret, timestamp, body))
+	return nil
+}
+
+// VerifyRequest : Checks that the request has been signed by oratio with the shared secret, within the tolerance.
+// The body is read and restored, so that it can be decoded afterwards.
+func VerifyRequest(r *http.Request, secret []byte, tolerance time.Duration) error {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(r.Body); err != nil {
+			return err
+		}
+		_ = r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	return Verify(secret, r.Header.Get(TimestampHeader), r.Header.Get(SignatureHeader), body, tolerance)
+}
+
+// VerifyMiddleware : Wraps the handler of an ability so that it only serves the requests signed by oratio with the
+// shared secret, signed within the default tolerance. The other requests are answered with a 401 status.
+func VerifyMiddleware(secret []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := VerifyRequest(r, secret, DefaultSignatureTolerance); err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(ErrorBody{Error: ErrorDetail{Code: "UNAUTHORIZED", Message: err.Error()}})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// VerifySignature : Checks that a request sent by the ability to oratio, the final response of a job for example, has
+// been signed with the secret shared with it, within the default tolerance
+func (c *Client) VerifySignature(timestamp string, signature string, body []byte) error {
+	if len(c.secret) == 0 {
+		return ErrNoSecret
+	}
+	return Verify(c.secret, timestamp, signature, body, DefaultSignatureTolerance)
+}
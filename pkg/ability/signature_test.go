@@ -0,0 +1,156 @@
+package ability
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	secret := []byte("shared secret")
+	body := []byte(`{"nlu":{"BestIntent":"GET_TIME"}}`)
+	now := time.Now().Unix()
+	signature := Sign(secret, now, body)
+
+	tests := []struct {
+		name      string
+		secret    []byte
+		timestamp string
+		signature string
+		body      []byte
+		tolerance time.Duration
+		want      error
+	}{
+		{"valid", secret, strconv.FormatInt(now, 10), signature, body, DefaultSignatureTolerance, nil},
+		{"tampered body", secret, strconv.FormatInt(now, 10), signature, []byte(`{"nlu":{"BestIntent":"STOP"}}`), DefaultSignatureTolerance, ErrInvalidSignature},
+		{"tampered timestamp", secret, strconv.FormatInt(now+1, 10), signature, body, DefaultSignatureTolerance, ErrInvalidSignature},
+		{"other secret", []byte("other secret"), strconv.FormatInt(now, 10), signature, body, DefaultSignatureTolerance, ErrInvalidSignature},
+		{"missing prefix", secret, strconv.FormatInt(now, 10), strings.TrimPrefix(signature, signaturePrefix), body, DefaultSignatureTolerance, ErrInvalidSignature},
+		{"missing timestamp", secret, "", signature, body, DefaultSignatureTolerance, ErrMissingSignature},
+		{"missing signature", secret, strconv.FormatInt(now, 10), "", body, DefaultSignatureTolerance, ErrMissingSignature},
+		{"invalid timestamp", secret, "now", signature, body, DefaultSignatureTolerance, ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(tt.secret, tt.timestamp, tt.signature, tt.body, tt.tolerance); !errors.Is(err, tt.want) {
+				t.Errorf("Verify() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyExpiry(t *testing.T) {
+	secret := []byte("shared secret")
+	body := []byte("{}")
+
+	tests := []struct {
+		name      string
+		age       time.Duration
+		tolerance time.Duration
+		want      error
+	}{
+		{"within tolerance", time.Minute, DefaultSignatureTolerance, nil},
+		{"too old", DefaultSignatureTolerance + time.Minute, DefaultSignatureTolerance, ErrExpiredSignature},
+		{"in the future", -DefaultSignatureTolerance - time.Minute, DefaultSignatureTolerance, ErrExpiredSignature},
+		{"no tolerance", 24 * time.Hour, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timestamp := time.Now().Add(-tt.age).Unix()
+			err := Verify(secret, strconv.FormatInt(timestamp, 10), Sign(secret, timestamp, body), body, tt.tolerance)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Verify() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyRequest(t *testing.T) {
+	secret := []byte("shared secret")
+	body := `{"nlu":{"BestIntent":"GET_TIME"}}`
+	timestamp := time.Now().Unix()
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		body    string
+		want    error
+	}{
+		{
+			name:    "signed",
+			headers: map[string]string{TimestampHeader: strconv.FormatInt(timestamp, 10), SignatureHeader: Sign(secret, timestamp, []byte(body))},
+			body:    body,
+		},
+		{
+			name:    "tampered",
+			headers: map[string]string{TimestampHeader: strconv.FormatInt(timestamp, 10), SignatureHeader: Sign(secret, timestamp, []byte(body))},
+			body:    `{"nlu":{"BestIntent":"STOP"}}`,
+			want:    ErrInvalidSignature,
+		},
+		{
+			name: "expired",
+			headers: map[string]string{
+				TimestampHeader: strconv.FormatInt(timestamp-3600, 10),
+				SignatureHeader: Sign(secret, timestamp-3600, []byte(body)),
+			},
+			body: body,
+			want: ErrExpiredSignature,
+		},
+		{name: "unsigned", body: body, want: ErrMissingSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := http.NewRequest("POST", "http://ability/resolve", strings.NewReader(tt.body))
+			for name, value := range tt.headers {
+				r.Header.Set(name, value)
+			}
+			if err := VerifyRequest(r, secret, DefaultSignatureTolerance); !errors.Is(err, tt.want) {
+				t.Errorf("VerifyRequest() = %v, want %v", err, tt.want)
+			}
+			if restored, _ := ioutil.ReadAll(r.Body); string(restored) != tt.body {
+				t.Errorf("VerifyRequest() left the body %q, want %q", restored, tt.body)
+			}
+		})
+	}
+}
+
+func TestSignRequest(t *testing.T) {
+	secret := []byte("shared secret")
+	body := []byte(`{"nlg":{"sentence":"Done"}}`)
+	r, _ := http.NewRequest("POST", "http://oratio/api/v1/abilities/clock/jobs/1", bytes.NewReader(body))
+	if err := SignRequest(r, secret); err != nil {
+		t.Fatalf("SignRequest() = %v", err)
+	}
+	if err := VerifyRequest(r, secret, DefaultSignatureTolerance); err != nil {
+		t.Errorf("VerifyRequest() of a signed request = %v", err)
+	}
+}
+
+func TestClientVerifySignature(t *testing.T) {
+	secret := []byte("shared secret")
+	body := []byte(`{"nlg":{"sentence":"Done"}}`)
+	timestamp := time.Now().Unix()
+	signature := Sign(secret, timestamp, body)
+
+	tests := []struct {
+		name   string
+		client *Client
+		want   error
+	}{
+		{"shared secret", NewClient("localhost", 10300, "clock", WithSigningSecret(secret)), nil},
+		{"other secret", NewClient("localhost", 10300, "clock", WithSigningSecret([]byte("other secret"))), ErrInvalidSignature},
+		{"no secret", NewClient("localhost", 10300, "clock"), ErrNoSecret},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.client.VerifySignature(strconv.FormatInt(timestamp, 10), signature, body); !errors.Is(err, tt.want) {
+				t.Errorf("VerifySignature() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
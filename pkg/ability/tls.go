@@ -0,0 +1,65 @@
+package ability
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// LoadTLSConfig : TLS configuration trusting the CA of the PEM file, presenting the client certificate of the PEM files
+// for mTLS, and checking the server name. Every argument is optional, the system CAs being trusted if there is no CA
+// file and the server name being the host requested if empty.
+func LoadTLSConfig(caFile string, certFile string, keyFile string, serverName string) (*tls.Config, error) {
+	config := &tls.Config{ServerName: serverName, MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		ca, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, errors.New("no certificate found in the CA file " + caFile)
+		}
+	}
+	if certFile != "" || keyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
+
+// WithTLSConfig : TLS configuration of the calls to the instances with an https URL, or to the gRPC instances (the
+// default one if omitted). The configuration should be shared by the clients of the same ability, as it identifies
+// the connections reused between them.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(c *Client) {
+		c.tlsConfig = config
+		if config != nil {
+			c.client.Transport = transport(config)
+		}
+	}
+}
+
+// transports : HTTP transports by TLS configuration, shared by the clients as they can be rebuilt at every request
+var transports = struct {
+	sync.Mutex
+	transports map[*tls.Config]*http.Transport
+}{transports: make(map[*tls.Config]*http.Transport)}
+
+func transport(config *tls.Config) *http.Transport {
+	transports.Lock()
+	defer transports.Unlock()
+	if t, ok := transports.transports[config]; ok {
+		return t
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = config
+	transports.transports[config] = t
+	return t
+}
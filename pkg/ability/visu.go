@@ -0,0 +1,116 @@
+package ability
+
+import "fmt"
+
+// VisuType : Kind of content displayed by the device
+type VisuType string
+
+const (
+	// VisuCard : A title and a text, with an optional image
+	VisuCard VisuType = "card"
+	// VisuList : A list of items to choose from
+	VisuList VisuType = "list"
+	// VisuMedia : A media being played
+	VisuMedia VisuType = "media"
+)
+
+// Visu : Content displayed by the devices having a screen, along with the vocal response. Only the field matching
+// the type is given.
+type Visu struct {
+	Version int      `json:"version,omitempty"`
+	Type    VisuType `json:"type"`
+	Card    *Card    `json:"card,omitempty"`
+	List    *List    `json:"list,omitempty"`
+	Media   *Media   `json:"media,omitempty"`
+}
+
+// Card : A title and a text, with an optional image
+type Card struct {
+	Title string `json:"title"`
+	Text  string `json:"text,omitempty"`
+	// Image : URL of the image
+	Image string `json:"image,omitempty"`
+}
+
+// List : A list of items to choose from
+type List struct {
+	Title string     `json:"title,omitempty"`
+	Items []ListItem `json:"items"`
+}
+
+// ListItem : An item of a List
+type ListItem struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+	// Image : URL of the image
+	Image string `json:"image,omitempty"`
+}
+
+// MediaKind : Kind of a media
+type MediaKind string
+
+const (
+	MediaAudio MediaKind = "audio"
+	MediaVideo MediaKind = "video"
+)
+
+// Media : A media to play
+type Media struct {
+	Kind MediaKind `json:"kind"`
+	// URL of the media stream
+	URL    string `json:"url"`
+	Title  string `json:"title,omitempty"`
+	Artist string `json:"artist,omitempty"`
+	// Image : URL of the cover
+	Image string `json:"image,omitempty"`
+}
+
+// DecodeVisu : Typed visu of a response, nil if it has none, or a *SchemaError if it doesn't match the schema
+func DecodeVisu(payload interface{}) (*Visu, error) {
+	if payload == nil {
+		return nil, nil
+	}
+	visu := &Visu{}
+	if err := decodeStrictly("visu", payload, visu); err != nil {
+		return nil, err
+	}
+	return visu, visu.Validate()
+}
+
+// Validate : Checks the visu matches the schema, returning a *SchemaError otherwise
+func (v *Visu) Validate() error {
+	if err := checkVersion("visu", v.Version); err != nil {
+		return err
+	}
+	switch v.Type {
+	case VisuCard:
+		if v.Card == nil {
+			return &SchemaError{Field: "visu.card", Reason: "missing"}
+		}
+		return required("visu.card.title", v.Card.Title)
+	case VisuList:
+		if v.List == nil || len(v.List.Items) == 0 {
+			return &SchemaError{Field: "visu.list.items", Reason: "missing"}
+		}
+		for i, item := range v.List.Items {
+			if err := required(fmt.Sprintf("visu.list.items[%d].title", i), item.Title); err != nil {
+				return err
+			}
+		}
+		return nil
+	case VisuMedia:
+		if v.Media == nil {
+			return &SchemaError{Field: "visu.media", Reason: "missing"}
+		}
+		return v.Media.validate("visu.media")
+	default:
+		return &SchemaError{Field: "visu.type", Reason: fmt.Sprintf("unknown type %q", v.Type)}
+	}
+}
+
+func (m *Media) validate(field string) error {
+	if m.Kind != MediaAudio && m.Kind != MediaVideo {
+		return &SchemaError{Field: field + ".kind", Reason: fmt.Sprintf("unknown kind %q", m.Kind)}
+	}
+	return required(field+".url", m.URL)
+}